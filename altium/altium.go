@@ -0,0 +1,261 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package altium reads Altium Designer pick and place files and BOMs.
+package altium
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+)
+
+// milsPerMM is the number of mils in a millimetre.
+const milsPerMM = 1 / 0.0254
+
+// table is the tabular part of an Altium report (with the preamble removed).
+type table struct {
+	// columns maps the (lower case) column names onto their index, with any
+	// unit suffix removed (eg. "Center-X(mm)" becomes "center-x").
+	columns map[string]int
+	// units holds the units of each column (if specified in the header).
+	units map[int]string
+	// defaultUnits are the units from the preamble ("Units used: mm").
+	defaultUnits string
+	// starts holds the position (in runes) of each column in the header line of
+	// the whitespace aligned text format.
+	starts []int
+	rows   [][]string
+}
+
+// readTable reads an Altium report, skipping the preamble. The header row is the
+// first row with a "Designator" column. Both the CSV and the (whitespace aligned)
+// text report formats are supported. The columns of the text format are found
+// from the positions of the headings, as values may contain spaces.
+func readTable(r io.Reader) (*table, error) {
+	t := table{
+		columns: make(map[string]int),
+		units:   make(map[int]string),
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var header []string
+	var delimited bool
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+
+		if header == nil {
+			if units, ok := cutUnitsLine(line); ok {
+				t.defaultUnits = units
+				continue
+			}
+
+			delimited = strings.Contains(line, ",")
+
+			fields, err := splitLine(line, delimited)
+			if err != nil || !csvx.HasColumns(fields, "Designator") {
+				continue
+			}
+
+			header = fields
+			if !delimited {
+				t.starts = columnStarts(line)
+			}
+			for i, name := range header {
+				name, units := cutUnits(name)
+				t.columns[strings.ToLower(name)] = i
+				if units != "" {
+					t.units[i] = units
+				}
+			}
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var fields []string
+		var err error
+		if delimited {
+			fields, err = splitLine(line, true)
+		} else {
+			fields, err = t.splitAligned(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse row: %w", err)
+		}
+
+		// Pad short rows, so missing trailing columns are empty.
+		for len(fields) < len(header) {
+			fields = append(fields, "")
+		}
+
+		t.rows = append(t.rows, fields)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	if header == nil {
+		return nil, fmt.Errorf("could not find header row")
+	}
+
+	return &t, nil
+}
+
+// column returns the index of the first of the named columns that is present.
+func (t *table) column(names ...string) (int, bool) {
+	for _, name := range names {
+		if i, ok := t.columns[name]; ok {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// length parses a length from a row, converting it to millimetres. The units
+// are taken from the value (eg. "10.5mil"), the column header or the preamble,
+// in that order (defaulting to millimetres).
+func (t *table) length(row []string, col int) (float64, error) {
+	value := strings.TrimSpace(row[col])
+
+	units := t.defaultUnits
+	if colUnits, ok := t.units[col]; ok {
+		units = colUnits
+	}
+
+	lower := strings.ToLower(value)
+	for _, suffix := range []string{"mm", "mil"} {
+		if strings.HasSuffix(lower, suffix) {
+			value = strings.TrimSpace(value[:len(value)-len(suffix)])
+			units = suffix
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid length: %q", row[col])
+	}
+
+	switch units {
+	case "", "mm":
+		return n, nil
+	case "mil":
+		return n / milsPerMM, nil
+	default:
+		return 0, fmt.Errorf("unsupported units: %s", units)
+	}
+}
+
+// splitLine splits a line into fields, either as CSV or separated by whitespace
+// (see csvx.SplitFields).
+func splitLine(line string, delimited bool) ([]string, error) {
+	if delimited {
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.TrimLeadingSpace = true
+		reader.LazyQuotes = true
+		return reader.Read()
+	}
+
+	return csvx.SplitFields(line)
+}
+
+// columnStarts returns the position (in runes) of each field of a whitespace
+// separated header line.
+func columnStarts(line string) []int {
+	var starts []int
+
+	inField := false
+	for i, r := range []rune(line) {
+		space := unicode.IsSpace(r)
+		if !space && !inField {
+			starts = append(starts, i)
+		}
+		inField = !space
+	}
+
+	return starts
+}
+
+// splitAligned splits a row of the whitespace aligned text format into fields,
+// using the positions of the columns in the header line. Values that don't line
+// up with the columns are rejected (rather than shifting the remaining columns).
+func (t *table) splitAligned(line string) ([]string, error) {
+	runes := []rune(line)
+
+	fields := make([]string, len(t.starts))
+	for i, start := range t.starts {
+		if start >= len(runes) {
+			break
+		}
+
+		if start > 0 && !unicode.IsSpace(runes[start-1]) {
+			return nil, fmt.Errorf("value is not aligned with column %d: %q", i+1, line)
+		}
+
+		end := len(runes)
+		if i+1 < len(t.starts) {
+			end = min(t.starts[i+1], len(runes))
+		}
+
+		value := strings.TrimSpace(string(runes[start:end]))
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		fields[i] = value
+	}
+
+	return fields, nil
+}
+
+// cutUnitsLine extracts the units from a preamble line (eg. "Units used: mm").
+func cutUnitsLine(line string) (string, bool) {
+	label, units, ok := strings.Cut(line, ":")
+	if !ok || !strings.EqualFold(strings.TrimSpace(label), "Units used") {
+		return "", false
+	}
+
+	return strings.ToLower(strings.TrimSpace(units)), true
+}
+
+// cutUnits splits a column name into its name and units (eg. "Center-X(mm)").
+func cutUnits(name string) (string, string) {
+	name = strings.TrimSpace(name)
+
+	open := strings.LastIndexByte(name, '(')
+	if open < 0 || !strings.HasSuffix(name, ")") {
+		return name, ""
+	}
+
+	units := strings.ToLower(name[open+1 : len(name)-1])
+	if units != "mm" && units != "mil" {
+		return name, ""
+	}
+
+	return strings.TrimSpace(name[:open]), units
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package altium
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+)
+
+func init() {
+	bom.RegisterReader(&bomReader{})
+}
+
+// lcscColumns are the (lower case) names of BOM columns that may hold an LCSC
+// part number.
+var lcscColumns = []string{"lcsc", "lcsc pn", "lcsc part", "lcsc part #", "lcsc part number", "lcsc#"}
+
+// ReadBOM reads an Altium BOM report (CSV or text).
+func ReadBOM(r io.Reader) ([]bom.Entry, error) {
+	t, err := readTable(r)
+	if err != nil {
+		return nil, err
+	}
+
+	refCol, _ := t.column("designator")
+	valCol, hasVal := t.column("comment", "value")
+	fpCol, hasFP := t.column("footprint")
+	qtyCol, hasQty := t.column("quantity", "qty")
+	lcscCol, hasLCSC := t.column(lcscColumns...)
+
+	entries := make([]bom.Entry, 0, len(t.rows))
+	for _, row := range t.rows {
+		var entry bom.Entry
+
+		if err := entry.Reference.UnmarshalText([]byte(row[refCol])); err != nil {
+			return nil, fmt.Errorf("could not parse designators: %w", err)
+		}
+
+		if hasVal {
+			entry.Value = row[valCol]
+		}
+
+		if hasFP {
+			entry.Footprint = row[fpCol]
+		}
+
+		entry.Qty = len(entry.Reference)
+		if hasQty && strings.TrimSpace(row[qtyCol]) != "" {
+			if entry.Qty, err = strconv.Atoi(strings.TrimSpace(row[qtyCol])); err != nil {
+				return nil, fmt.Errorf("invalid quantity: %q", row[qtyCol])
+			}
+		}
+
+		if hasLCSC {
+			entry.LCSC = strings.TrimSpace(row[lcscCol])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// bomReader reads Altium BOM reports.
+type bomReader struct{}
+
+func (r *bomReader) Name() string {
+	return "altium"
+}
+
+func (r *bomReader) Sniff(name string, head []byte) bool {
+	return bytes.Contains(head, []byte("Designator")) &&
+		bytes.Contains(head, []byte("Comment")) &&
+		bytes.Contains(head, []byte("Quantity"))
+}
+
+func (r *bomReader) Read(rd io.Reader) ([]bom.Entry, error) {
+	return ReadBOM(rd)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package altium
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+)
+
+func init() {
+	placement.RegisterReader(&placementReader{})
+}
+
+// ReadPlacements reads the component placements from an Altium pick and place
+// file (CSV or text). Coordinates are converted to millimetres.
+func ReadPlacements(r io.Reader) ([]placement.Placement, error) {
+	t, err := readTable(r)
+	if err != nil {
+		return nil, err
+	}
+
+	refCol, _ := t.column("designator")
+	valCol, hasVal := t.column("comment")
+	pkgCol, hasPkg := t.column("footprint")
+	rotCol, hasRot := t.column("rotation")
+
+	layerCol, ok := t.column("layer", "tb")
+	if !ok {
+		return nil, fmt.Errorf("missing layer column")
+	}
+
+	xCol, ok := t.column("center-x", "mid x", "ref-x", "ref x")
+	if !ok {
+		return nil, fmt.Errorf("missing X coordinate column")
+	}
+
+	yCol, ok := t.column("center-y", "mid y", "ref-y", "ref y")
+	if !ok {
+		return nil, fmt.Errorf("missing Y coordinate column")
+	}
+
+	placements := make([]placement.Placement, 0, len(t.rows))
+	for _, row := range t.rows {
+		p := placement.Placement{
+			Ref: row[refCol],
+		}
+
+		if hasVal {
+			p.Val = row[valCol]
+		}
+
+		if hasPkg {
+			p.Package = row[pkgCol]
+		}
+
+		if p.Side, err = parseLayer(row[layerCol]); err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", p.Ref, err)
+		}
+
+		if p.PosX, err = t.length(row, xCol); err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", p.Ref, err)
+		}
+
+		if p.PosY, err = t.length(row, yCol); err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", p.Ref, err)
+		}
+
+		if hasRot && row[rotCol] != "" {
+			if p.Rot, err = strconv.ParseFloat(strings.TrimSpace(row[rotCol]), 64); err != nil {
+				return nil, fmt.Errorf("could not parse %s: invalid rotation: %q", p.Ref, row[rotCol])
+			}
+		}
+
+		placements = append(placements, p)
+	}
+
+	return placements, nil
+}
+
+// parseLayer converts an Altium layer (eg. "TopLayer") into a placement side.
+func parseLayer(layer string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(layer)) {
+	case "toplayer", "top", "t":
+		return "top", nil
+	case "bottomlayer", "bottom", "b":
+		return "bottom", nil
+	default:
+		return "", fmt.Errorf("invalid layer: %q", layer)
+	}
+}
+
+// placementReader reads Altium pick and place files.
+type placementReader struct{}
+
+func (r *placementReader) Name() string {
+	return "altium"
+}
+
+func (r *placementReader) Sniff(name string, head []byte) bool {
+	return bytes.Contains(head, []byte("Designator")) &&
+		(bytes.Contains(head, []byte("Center-X")) || bytes.Contains(head, []byte("Pick and Place")))
+}
+
+func (r *placementReader) Read(rd io.Reader) ([]placement.Placement, error) {
+	return ReadPlacements(rd)
+}
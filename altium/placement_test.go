@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package altium_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/altium"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPlacements(t *testing.T) {
+	f, err := os.Open("testdata/pickplace.csv")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	placements, err := altium.ReadPlacements(f)
+	require.NoError(t, err)
+
+	expected := []placement.Placement{
+		{Ref: "C1", Val: "100nF", Package: "C0603", PosX: 10.16, PosY: 20.32, Rot: 90, Side: "top"},
+		{Ref: "R1", Val: "10k", Package: "R0603", PosX: 12.7, PosY: 20.32, Rot: 180, Side: "bottom"},
+		{Ref: "U1", Val: "AP2112K-3.3", Package: "SOT95P280X145-5N", PosX: 25.4, PosY: 15.24, Rot: 270, Side: "top"},
+	}
+
+	assert.Equal(t, expected, placements)
+}
+
+func TestReadPlacementsTextMils(t *testing.T) {
+	f, err := os.Open("testdata/pickplace.txt")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	placements, err := altium.ReadPlacements(f)
+	require.NoError(t, err)
+	require.Len(t, placements, 3)
+
+	assert.Equal(t, "C1", placements[0].Ref)
+	assert.Equal(t, "100nF", placements[0].Val)
+	assert.InDelta(t, 10.16, placements[0].PosX, 0.000001)
+	assert.InDelta(t, 20.32, placements[0].PosY, 0.000001)
+	assert.Equal(t, "bottom", placements[1].Side)
+	assert.InDelta(t, 270.0, placements[2].Rot, 0.000001)
+}
+
+func TestReadPlacementsTextSpaces(t *testing.T) {
+	placements, err := altium.ReadPlacements(strings.NewReader(`Units used: mm
+
+Designator Comment     Layer       Footprint Center-X(mm) Center-Y(mm) Rotation Description
+R1         10k 1%      TopLayer    R0603     10           20           90       "Resistor, 1%"
+R2         4k7 Ω       BottomLayer R0603     12           20           180      
+`))
+	require.NoError(t, err)
+
+	expected := []placement.Placement{
+		{Ref: "R1", Val: "10k 1%", Package: "R0603", PosX: 10, PosY: 20, Rot: 90, Side: "top"},
+		{Ref: "R2", Val: "4k7 Ω", Package: "R0603", PosX: 12, PosY: 20, Rot: 180, Side: "bottom"},
+	}
+
+	assert.Equal(t, expected, placements)
+
+	// Values that overflow their column can't be split reliably.
+	_, err = altium.ReadPlacements(strings.NewReader(`Designator Comment Layer Footprint Center-X(mm) Center-Y(mm) Rotation
+R1 10k 1% TopLayer R0603 10 20 90
+`))
+	require.Error(t, err)
+}
+
+func TestReadPlacementsValueUnits(t *testing.T) {
+	placements, err := altium.ReadPlacements(strings.NewReader(`Designator,Footprint,Mid X,Mid Y,TB,Rotation
+C1,C0603,400mil,20.32mm,B,90
+`))
+	require.NoError(t, err)
+	require.Len(t, placements, 1)
+
+	assert.InDelta(t, 10.16, placements[0].PosX, 0.000001)
+	assert.InDelta(t, 20.32, placements[0].PosY, 0.000001)
+	assert.Equal(t, "bottom", placements[0].Side)
+}
+
+func TestReadPlacementsInvalidLayer(t *testing.T) {
+	_, err := altium.ReadPlacements(strings.NewReader(`Designator,Layer,Center-X(mm),Center-Y(mm),Rotation
+C1,InnerLayer,1,2,0
+`))
+	require.Error(t, err)
+}
+
+func TestLoadPlacements(t *testing.T) {
+	for _, path := range []string{"testdata/pickplace.csv", "testdata/pickplace.txt"} {
+		placements, format, err := placement.Load(path, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "altium", format)
+		assert.Len(t, placements, 3)
+	}
+}
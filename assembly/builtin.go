@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package assembly
+
+import (
+	"sort"
+	"sync"
+)
+
+// JLCPCB is the JLCPCB assembly profile.
+var JLCPCB = Profile{
+	Name:      "jlcpcb",
+	Rotations: RotationsJLCPCB,
+	BOMColumns: []Column{
+		{Header: "Comment", Field: FieldComment},
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "Footprint", Field: FieldFootprint},
+		{Header: "LCSC Part Number", Field: FieldPartNumber},
+	},
+	PlacementColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "Mid X", Field: FieldX},
+		{Header: "Mid Y", Field: FieldY},
+		{Header: "Layer", Field: FieldLayer},
+		{Header: "Rotation", Field: FieldRotation},
+	},
+}
+
+// PCBWay is the PCBWay assembly profile (part numbers from the MPN field).
+var PCBWay = Profile{
+	Name:        "pcbway",
+	PartNumber:  FieldUserPrefix + "MPN",
+	TopLayer:    "T",
+	BottomLayer: "B",
+	BOMColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "Qty", Field: FieldQuantity},
+		{Header: "Mfg Part #", Field: FieldPartNumber},
+		{Header: "Description / Value", Field: FieldComment},
+		{Header: "Package/Footprint", Field: FieldFootprint},
+	},
+	PlacementColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "Footprint", Field: FieldFootprint},
+		{Header: "Mid X", Field: FieldX},
+		{Header: "Mid Y", Field: FieldY},
+		{Header: "Layer", Field: FieldLayer},
+		{Header: "Rotation", Field: FieldRotation},
+		{Header: "Comment", Field: FieldComment},
+	},
+}
+
+// Seeed is the Seeed Fusion assembly profile (part numbers from the MPN field).
+var Seeed = Profile{
+	Name:        "seeed",
+	PartNumber:  FieldUserPrefix + "MPN",
+	TopLayer:    "T",
+	BottomLayer: "B",
+	BOMColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "MPN/Seeed SKU", Field: FieldPartNumber},
+		{Header: "Qty", Field: FieldQuantity},
+		{Header: "Value", Field: FieldComment},
+		{Header: "Package", Field: FieldFootprint},
+	},
+	PlacementColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "Mid X", Field: FieldX},
+		{Header: "Mid Y", Field: FieldY},
+		{Header: "Layer", Field: FieldLayer},
+		{Header: "Rotation", Field: FieldRotation},
+	},
+}
+
+// MacroFab is the MacroFab assembly profile (XYRS placements, in mils, and
+// part numbers from the MPN field).
+var MacroFab = Profile{
+	Name:        "macrofab",
+	PartNumber:  FieldUserPrefix + "MPN",
+	Delimiter:   "\t",
+	Units:       "mil",
+	TopLayer:    "1",
+	BottomLayer: "2",
+	BOMColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "Quantity", Field: FieldQuantity},
+		{Header: "MPN", Field: FieldPartNumber},
+		{Header: "Value", Field: FieldComment},
+		{Header: "Footprint", Field: FieldFootprint},
+	},
+	PlacementColumns: []Column{
+		{Header: "Designator", Field: FieldDesignator},
+		{Header: "X-Loc", Field: FieldX},
+		{Header: "Y-Loc", Field: FieldY},
+		{Header: "Rotation", Field: FieldRotation},
+		{Header: "Side", Field: FieldLayer},
+		{Header: "Value", Field: FieldComment},
+		{Header: "Footprint", Field: FieldFootprint},
+	},
+}
+
+var (
+	profilesMu sync.RWMutex
+	profiles   = make(map[string]Profile)
+)
+
+func init() {
+	for _, p := range []Profile{JLCPCB, PCBWay, Seeed, MacroFab} {
+		Register(p)
+	}
+}
+
+// Register registers an assembly profile (replacing any existing profile with
+// the same name).
+func Register(p Profile) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	profiles[p.Name] = p
+}
+
+// Unregister removes a registered assembly profile.
+func Unregister(name string) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	delete(profiles, name)
+}
+
+// Lookup finds a registered assembly profile by name.
+func Lookup(name string) (*Profile, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+
+	p, ok := profiles[name]
+	if !ok {
+		return nil, false
+	}
+
+	return &p, true
+}
+
+// Names returns the names of all the registered assembly profiles.
+func Names() []string {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
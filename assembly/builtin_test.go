@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package assembly_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	assert.Subset(t, assembly.Names(), []string{"jlcpcb", "macrofab", "pcbway", "seeed"})
+
+	p, ok := assembly.Lookup("pcbway")
+	require.True(t, ok)
+	assert.Equal(t, "T", p.TopLayer)
+
+	_, ok = assembly.Lookup("unknown")
+	assert.False(t, ok)
+}
+
+func TestRegister(t *testing.T) {
+	assembly.Register(assembly.Profile{Name: "custom"})
+	t.Cleanup(func() { assembly.Unregister("custom") })
+
+	_, ok := assembly.Lookup("custom")
+	assert.True(t, ok)
+	assert.Contains(t, assembly.Names(), "custom")
+}
+
+func TestUnregister(t *testing.T) {
+	assembly.Register(assembly.Profile{Name: "temporary"})
+	assembly.Unregister("temporary")
+
+	_, ok := assembly.Lookup("temporary")
+	assert.False(t, ok)
+	assert.NotContains(t, assembly.Names(), "temporary")
+}
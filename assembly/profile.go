@@ -0,0 +1,373 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package assembly describes the upload formats of PCB assembly services, so
+// converted BOMs and component placements can be written for assemblers other
+// than JLCPCB.
+package assembly
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"gopkg.in/yaml.v3"
+)
+
+// BOM fields.
+const (
+	FieldDesignator = "designator"
+	FieldComment    = "comment"
+	FieldFootprint  = "footprint"
+	FieldQuantity   = "quantity"
+	FieldPartNumber = "part_number"
+)
+
+// FieldUserPrefix prefixes user defined BOM fields (eg. "field:MPN" for the
+// MPN column of the source BOM).
+const FieldUserPrefix = "field:"
+
+// PartNumberLCSC is the part number source for LCSC (JLCPCB) part numbers.
+const PartNumberLCSC = "lcsc"
+
+// RotationsJLCPCB selects JLCPCB's built-in rotation corrections.
+const RotationsJLCPCB = "jlcpcb"
+
+// Placement fields (in addition to designator, comment and footprint).
+const (
+	FieldX        = "x"
+	FieldY        = "y"
+	FieldLayer    = "layer"
+	FieldRotation = "rotation"
+)
+
+// Column is a single column in an assembler's upload file.
+type Column struct {
+	// Header is the column heading (eg. "LCSC Part Number").
+	Header string `json:"header" yaml:"header" toml:"header"`
+	// Field is what goes in the column (eg. FieldPartNumber).
+	Field string `json:"field" yaml:"field" toml:"field"`
+}
+
+// Profile describes an assembler's BOM and component placement upload format.
+type Profile struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// Delimiter is the field delimiter (defaults to ",").
+	Delimiter string `json:"delimiter,omitempty" yaml:"delimiter,omitempty" toml:"delimiter,omitempty"`
+	// Units are the units of placement coordinates ("mm", "mil" or "in",
+	// defaults to "mm").
+	Units string `json:"units,omitempty" yaml:"units,omitempty" toml:"units,omitempty"`
+	// TopLayer and BottomLayer are the names of the placement sides (default to
+	// "Top" and "Bottom").
+	TopLayer    string `json:"top_layer,omitempty" yaml:"top_layer,omitempty" toml:"top_layer,omitempty"`
+	BottomLayer string `json:"bottom_layer,omitempty" yaml:"bottom_layer,omitempty" toml:"bottom_layer,omitempty"`
+	// Clockwise is set if the assembler measures rotations clockwise (rather
+	// than JLCPCB's counter-clockwise).
+	Clockwise bool `json:"clockwise,omitempty" yaml:"clockwise,omitempty" toml:"clockwise,omitempty"`
+	// RotationOffset is added to every rotation (after any change in direction).
+	RotationOffset float64 `json:"rotation_offset,omitempty" yaml:"rotation_offset,omitempty" toml:"rotation_offset,omitempty"`
+	// PartNumber is the source of the BOM part number column, either
+	// PartNumberLCSC (the default) or a user defined BOM field (eg. "field:MPN").
+	PartNumber string `json:"part_number,omitempty" yaml:"part_number,omitempty" toml:"part_number,omitempty"`
+	// Rotations are the built-in rotation corrections applied to placements
+	// (RotationsJLCPCB, or none if empty). Corrections from the project
+	// configuration or --rotations are always applied.
+	Rotations string `json:"rotations,omitempty" yaml:"rotations,omitempty" toml:"rotations,omitempty"`
+	// BOMColumns and PlacementColumns are the columns of the upload files.
+	BOMColumns       []Column `json:"bom_columns" yaml:"bom_columns" toml:"bom_columns"`
+	PlacementColumns []Column `json:"placement_columns" yaml:"placement_columns" toml:"placement_columns"`
+}
+
+// LoadProfile loads a profile from a YAML or TOML file (based on the extension).
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	var p Profile
+	switch filepath.Ext(path) {
+	case ".toml":
+		if err := toml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("could not parse TOML: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("could not parse YAML: %w", err)
+		}
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Validate checks a profile for unknown fields and units.
+func (p *Profile) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("profile has no name")
+	}
+
+	if p.Delimiter != "" && utf8.RuneCountInString(p.Delimiter) != 1 {
+		return fmt.Errorf("profile %s: delimiter must be a single character", p.Name)
+	}
+
+	if _, ok := unitsPerMM[p.units()]; !ok {
+		return fmt.Errorf("profile %s: unsupported units: %s", p.Name, p.Units)
+	}
+
+	switch {
+	case p.PartNumber == "", p.PartNumber == PartNumberLCSC:
+	case strings.HasPrefix(p.PartNumber, FieldUserPrefix) && p.PartNumber != FieldUserPrefix:
+	default:
+		return fmt.Errorf("profile %s: unknown part number source: %s", p.Name, p.PartNumber)
+	}
+
+	switch p.Rotations {
+	case "", RotationsJLCPCB:
+	default:
+		return fmt.Errorf("profile %s: unknown rotation corrections: %s", p.Name, p.Rotations)
+	}
+
+	for _, col := range p.BOMColumns {
+		if name, ok := strings.CutPrefix(col.Field, FieldUserPrefix); ok && name != "" {
+			continue
+		}
+
+		switch col.Field {
+		case FieldDesignator, FieldComment, FieldFootprint, FieldQuantity, FieldPartNumber:
+		default:
+			return fmt.Errorf("profile %s: unknown BOM field: %s", p.Name, col.Field)
+		}
+	}
+
+	for _, col := range p.PlacementColumns {
+		switch col.Field {
+		case FieldDesignator, FieldComment, FieldFootprint, FieldX, FieldY, FieldLayer, FieldRotation:
+		default:
+			return fmt.Errorf("profile %s: unknown placement field: %s", p.Name, col.Field)
+		}
+	}
+
+	return nil
+}
+
+// WriteBOM writes converted BOM entries in the profile's format.
+func (p *Profile) WriteBOM(w io.Writer, entries []jlcpcb.BOMEntry) error {
+	header, rows := p.BOMTable(entries)
+	return p.write(w, header, rows)
+}
+
+// WritePlacements writes converted component placements in the profile's format.
+func (p *Profile) WritePlacements(w io.Writer, entries []jlcpcb.PlacementEntry) error {
+	header, rows := p.PlacementTable(entries)
+	return p.write(w, header, rows)
+}
+
+// BOMTable returns the header and rows of converted BOM entries in the profile's
+// format.
+func (p *Profile) BOMTable(entries []jlcpcb.BOMEntry) ([]string, [][]string) {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		row := make([]string, len(p.BOMColumns))
+		for i, col := range p.BOMColumns {
+			switch col.Field {
+			case FieldDesignator:
+				row[i] = entry.Designator
+			case FieldComment:
+				row[i] = entry.Comment
+			case FieldFootprint:
+				row[i] = entry.Footprint
+			case FieldQuantity:
+				row[i] = strconv.Itoa(entry.Source.Qty)
+			case FieldPartNumber:
+				row[i] = p.partNumber(entry)
+			default:
+				if name, ok := strings.CutPrefix(col.Field, FieldUserPrefix); ok {
+					row[i] = entry.Source.Field(name)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return headers(p.BOMColumns), rows
+}
+
+// PlacementTable returns the header and rows of converted component placements
+// in the profile's format.
+func (p *Profile) PlacementTable(entries []jlcpcb.PlacementEntry) ([]string, [][]string) {
+	scale := unitsPerMM[p.units()]
+
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		row := make([]string, len(p.PlacementColumns))
+		for i, col := range p.PlacementColumns {
+			switch col.Field {
+			case FieldDesignator:
+				row[i] = entry.Designator
+			case FieldComment:
+				row[i] = entry.Source.Val
+			case FieldFootprint:
+				row[i] = entry.Source.Package
+			case FieldX:
+				row[i] = formatNumber(entry.MidX * scale)
+			case FieldY:
+				row[i] = formatNumber(entry.MidY * scale)
+			case FieldLayer:
+				row[i] = p.layer(entry.Layer)
+			case FieldRotation:
+				row[i] = formatNumber(p.rotation(entry.Rotation))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return headers(p.PlacementColumns), rows
+}
+
+// Record is a converted BOM entry or component placement in a profile's format,
+// for structured (JSON/YAML) output.
+type Record[T any] struct {
+	// Columns maps the profile's column headers onto the values written to the
+	// upload file (in the profile's units, layer names and rotations).
+	Columns map[string]string `json:"columns" yaml:"columns"`
+	// Entry is the converted entry (in millimetres, with JLCPCB's layer names
+	// and rotations).
+	Entry T `json:"entry" yaml:"entry"`
+}
+
+// BOMRecords returns converted BOM entries in the profile's format.
+func (p *Profile) BOMRecords(entries []jlcpcb.BOMEntry) []Record[jlcpcb.BOMEntry] {
+	header, rows := p.BOMTable(entries)
+	return records(header, rows, entries)
+}
+
+// PlacementRecords returns converted component placements in the profile's
+// format.
+func (p *Profile) PlacementRecords(entries []jlcpcb.PlacementEntry) []Record[jlcpcb.PlacementEntry] {
+	header, rows := p.PlacementTable(entries)
+	return records(header, rows, entries)
+}
+
+func records[T any](header []string, rows [][]string, entries []T) []Record[T] {
+	records := make([]Record[T], 0, len(entries))
+	for i, entry := range entries {
+		columns := make(map[string]string, len(header))
+		for j, name := range header {
+			columns[name] = rows[i][j]
+		}
+		records = append(records, Record[T]{Columns: columns, Entry: entry})
+	}
+
+	return records
+}
+
+// partNumber returns the part number of a BOM entry from the profile's part
+// number source.
+func (p *Profile) partNumber(entry jlcpcb.BOMEntry) string {
+	if name, ok := strings.CutPrefix(p.PartNumber, FieldUserPrefix); ok {
+		return entry.Source.Field(name)
+	}
+	return entry.LCSC
+}
+
+func (p *Profile) write(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if p.Delimiter != "" {
+		writer.Comma, _ = utf8.DecodeRuneInString(p.Delimiter)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("could not write header: %w", err)
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("could not write rows: %w", err)
+	}
+
+	return nil
+}
+
+func headers(columns []Column) []string {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Header
+	}
+	return header
+}
+
+// unitsPerMM converts millimetres into each of the supported units.
+var unitsPerMM = map[string]float64{
+	"mm":  1,
+	"mil": 1 / 0.0254,
+	"in":  1 / 25.4,
+}
+
+func (p *Profile) units() string {
+	if p.Units == "" {
+		return "mm"
+	}
+	return p.Units
+}
+
+// layer returns the profile's name for a JLCPCB layer ("Top" or "Bottom").
+func (p *Profile) layer(layer string) string {
+	switch {
+	case layer == "Top" && p.TopLayer != "":
+		return p.TopLayer
+	case layer == "Bottom" && p.BottomLayer != "":
+		return p.BottomLayer
+	default:
+		return layer
+	}
+}
+
+// rotation converts a JLCPCB rotation into the profile's convention.
+func (p *Profile) rotation(rot float64) float64 {
+	if p.Clockwise {
+		rot = -rot
+	}
+
+	rot = math.Mod(rot+p.RotationOffset, 360)
+	if rot < 0 {
+		rot += 360
+	}
+
+	return rot
+}
+
+// formatNumber formats a number with at most 4 decimal places.
+func formatNumber(n float64) string {
+	n = math.Round(n*1e4) / 1e4
+	if n == 0 {
+		// Avoid "-0"
+		n = 0
+	}
+
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
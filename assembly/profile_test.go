@@ -0,0 +1,230 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package assembly_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bomEntries = []jlcpcb.BOMEntry{
+		{
+			Comment:    "100n",
+			Designator: "C1,C2",
+			Footprint:  "0603",
+			LCSC:       "C14663",
+			Source: bom.Entry{
+				Reference: bom.Designators{"C1", "C2"},
+				Qty:       2,
+				Fields:    map[string]string{"MPN": "CL10B104KB8NNNC"},
+			},
+		},
+	}
+
+	placementEntries = []jlcpcb.PlacementEntry{
+		{
+			Designator: "C1",
+			MidX:       10.16,
+			MidY:       20.32,
+			Layer:      "Top",
+			Rotation:   90,
+			Source:     placement.Placement{Ref: "C1", Val: "100n", Package: "C_0603_1608Metric"},
+		},
+		{
+			Designator: "C2",
+			MidX:       25.4,
+			MidY:       -2.54,
+			Layer:      "Bottom",
+			Rotation:   0,
+			Source:     placement.Placement{Ref: "C2", Val: "100n", Package: "C_0603_1608Metric"},
+		},
+	}
+)
+
+func TestWriteBOM(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, assembly.JLCPCB.WriteBOM(&sb, bomEntries))
+
+	assert.Equal(t, `Comment,Designator,Footprint,LCSC Part Number
+100n,"C1,C2",0603,C14663
+`, sb.String())
+
+	sb.Reset()
+	require.NoError(t, assembly.PCBWay.WriteBOM(&sb, bomEntries))
+
+	assert.Equal(t, `Designator,Qty,Mfg Part #,Description / Value,Package/Footprint
+"C1,C2",2,CL10B104KB8NNNC,100n,0603
+`, sb.String())
+}
+
+func TestWriteBOMUserFields(t *testing.T) {
+	entries := []jlcpcb.BOMEntry{{
+		Comment:    "100n",
+		Designator: "C1",
+		LCSC:       "C14663",
+		Source: bom.Entry{
+			Reference: bom.Designators{"C1"},
+			Fields:    map[string]string{"MPN": "CL10B104KB8NNNC"},
+		},
+	}}
+
+	p := assembly.Profile{
+		Name: "mpn",
+		BOMColumns: []assembly.Column{
+			{Header: "Designator", Field: assembly.FieldDesignator},
+			{Header: "MPN", Field: assembly.FieldUserPrefix + "mpn"},
+			{Header: "Manufacturer", Field: assembly.FieldUserPrefix + "Manufacturer"},
+		},
+	}
+	require.NoError(t, p.Validate())
+
+	var sb strings.Builder
+	require.NoError(t, p.WriteBOM(&sb, entries))
+
+	assert.Equal(t, `Designator,MPN,Manufacturer
+C1,CL10B104KB8NNNC,
+`, sb.String())
+}
+
+func TestWriteBOMPartNumber(t *testing.T) {
+	p := assembly.Profile{
+		Name:       "lcsc",
+		PartNumber: assembly.PartNumberLCSC,
+		BOMColumns: []assembly.Column{{Header: "Part", Field: assembly.FieldPartNumber}},
+	}
+	require.NoError(t, p.Validate())
+
+	var sb strings.Builder
+	require.NoError(t, p.WriteBOM(&sb, bomEntries))
+	assert.Equal(t, "Part\nC14663\n", sb.String())
+
+	p.PartNumber = assembly.FieldUserPrefix + "mpn"
+	require.NoError(t, p.Validate())
+
+	sb.Reset()
+	require.NoError(t, p.WriteBOM(&sb, bomEntries))
+	assert.Equal(t, "Part\nCL10B104KB8NNNC\n", sb.String())
+
+	// Only JLCPCB gets LCSC part numbers and JLCPCB's rotation corrections.
+	for _, name := range []string{"pcbway", "seeed", "macrofab"} {
+		p, ok := assembly.Lookup(name)
+		require.True(t, ok)
+		assert.Equal(t, assembly.FieldUserPrefix+"MPN", p.PartNumber, name)
+		assert.Empty(t, p.Rotations, name)
+	}
+	assert.Equal(t, assembly.RotationsJLCPCB, assembly.JLCPCB.Rotations)
+}
+
+func TestWritePlacements(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, assembly.JLCPCB.WritePlacements(&sb, placementEntries))
+
+	assert.Equal(t, `Designator,Mid X,Mid Y,Layer,Rotation
+C1,10.16,20.32,Top,90
+C2,25.4,-2.54,Bottom,0
+`, sb.String())
+
+	sb.Reset()
+	require.NoError(t, assembly.MacroFab.WritePlacements(&sb, placementEntries))
+
+	assert.Equal(t, "Designator\tX-Loc\tY-Loc\tRotation\tSide\tValue\tFootprint\n"+
+		"C1\t400\t800\t90\t1\t100n\tC_0603_1608Metric\n"+
+		"C2\t1000\t-100\t0\t2\t100n\tC_0603_1608Metric\n", sb.String())
+}
+
+func TestPlacementRecords(t *testing.T) {
+	records := assembly.MacroFab.PlacementRecords(placementEntries)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, map[string]string{
+		"Designator": "C1",
+		"X-Loc":      "400",
+		"Y-Loc":      "800",
+		"Rotation":   "90",
+		"Side":       "1",
+		"Value":      "100n",
+		"Footprint":  "C_0603_1608Metric",
+	}, records[0].Columns)
+	assert.Equal(t, placementEntries[0], records[0].Entry)
+
+	bomRecords := assembly.PCBWay.BOMRecords(bomEntries)
+	require.Len(t, bomRecords, 1)
+	assert.Equal(t, "CL10B104KB8NNNC", bomRecords[0].Columns["Mfg Part #"])
+}
+
+func TestLoadProfile(t *testing.T) {
+	p, err := assembly.LoadProfile("testdata/profile.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme", p.Name)
+
+	var sb strings.Builder
+	require.NoError(t, p.WritePlacements(&sb, placementEntries))
+
+	assert.Equal(t, `Ref;X;Y;Side;Angle
+C1;0.4;0.8;TOP;270
+C2;1;-0.1;BOT;0
+`, sb.String())
+
+	sb.Reset()
+	require.NoError(t, p.WriteBOM(&sb, bomEntries))
+
+	assert.Equal(t, `Part;Refs
+C14663;C1,C2
+`, sb.String())
+}
+
+func TestValidate(t *testing.T) {
+	p := assembly.Profile{
+		Name:       "broken",
+		BOMColumns: []assembly.Column{{Header: "MPN", Field: "mpn"}},
+	}
+	assert.Error(t, p.Validate())
+
+	p = assembly.Profile{
+		Name:       "broken",
+		BOMColumns: []assembly.Column{{Header: "MPN", Field: assembly.FieldUserPrefix}},
+	}
+	assert.Error(t, p.Validate())
+
+	p = assembly.Profile{Name: "broken", Units: "furlongs"}
+	assert.Error(t, p.Validate())
+
+	p = assembly.Profile{Name: "broken", Delimiter: ",,"}
+	assert.Error(t, p.Validate())
+
+	p = assembly.Profile{Name: "broken", PartNumber: "mpn"}
+	assert.Error(t, p.Validate())
+
+	p = assembly.Profile{Name: "broken", Rotations: "pcbway"}
+	assert.Error(t, p.Validate())
+
+	for _, name := range assembly.Names() {
+		p, ok := assembly.Lookup(name)
+		require.True(t, ok)
+		assert.NoError(t, p.Validate(), name)
+	}
+}
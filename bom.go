@@ -0,0 +1,502 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/dpeckett/jlcfabtool/spreadsheet"
+)
+
+type bomConvertOptions struct {
+	inputFormat string
+	// sheet selects the sheet of an xlsx BOM.
+	sheet            string
+	footprintMapFile string
+	// footprintOverrides are additional footprint mappings (eg. from the
+	// project configuration), they are combined with the footprint map file.
+	footprintOverrides jlcpcb.FootprintTable
+	consolidate        bool
+	profile            *assembly.Profile
+	format             string
+	// summary adds DNP and warnings sheets to xlsx output.
+	summary bool
+	// pricesFile is a price list used to add a cost sheet to xlsx output.
+	pricesFile string
+	policy     bomPolicy
+}
+
+func convertBOM(file string, opts bomConvertOptions) error {
+	if err := checkConvertFormat(opts.format); err != nil {
+		return err
+	}
+
+	if opts.format != "xlsx" && (opts.summary || opts.pricesFile != "") {
+		return fmt.Errorf("--summary and --prices are only supported with --format xlsx")
+	}
+
+	slog.Info("Converting BOM", slog.Any("file", file))
+
+	entries, err := loadBOM(file, opts.inputFormat, opts.sheet, opts.policy)
+	if err != nil {
+		return err
+	}
+
+	warnings := bom.Check(entries)
+
+	entries, dnp := bom.SplitDNP(entries)
+	if len(dnp) > 0 {
+		slog.Info("Excluding DNP parts", slog.Int("entries", len(dnp)))
+	}
+
+	if opts.consolidate {
+		var consolidateWarnings []string
+		entries, consolidateWarnings = bom.Consolidate(entries)
+		warnings = append(warnings, consolidateWarnings...)
+	}
+
+	for _, warning := range warnings {
+		slog.Warn(warning)
+	}
+
+	footprintOverrides := opts.footprintOverrides
+	if opts.footprintMapFile != "" {
+		f, err := os.Open(opts.footprintMapFile)
+		if err != nil {
+			return fmt.Errorf("error opening footprint map: %w", err)
+		}
+		defer f.Close()
+
+		mappings, err := jlcpcb.LoadFootprintTable(f)
+		if err != nil {
+			return fmt.Errorf("error loading footprint map: %w", err)
+		}
+
+		footprintOverrides = append(mappings, footprintOverrides...)
+	}
+
+	converted := jlcpcb.ConvertBOM(entries, footprintOverrides)
+
+	f, err := os.Create(outputPath(file, opts.profile.Name, opts.format))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer f.Close()
+
+	switch opts.format {
+	case "csv":
+		return opts.profile.WriteBOM(f, converted)
+	case "xlsx":
+		var prices jlcpcb.PriceList
+		if opts.pricesFile != "" {
+			pf, err := os.Open(opts.pricesFile)
+			if err != nil {
+				return fmt.Errorf("error opening price list: %w", err)
+			}
+			defer pf.Close()
+
+			if prices, err = jlcpcb.LoadPriceList(pf); err != nil {
+				return fmt.Errorf("error loading price list: %w", err)
+			}
+		}
+
+		return writeBOMWorkbook(f, opts, converted, dnp, warnings, prices)
+	default:
+		return writeReport(f, opts.format, opts.profile.BOMRecords(converted), warnings)
+	}
+}
+
+// writeBOMWorkbook writes a converted BOM as an xlsx workbook, with optional
+// summary sheets for DNP parts, warnings and cost.
+func writeBOMWorkbook(w io.Writer, opts bomConvertOptions, converted []jlcpcb.BOMEntry, dnp []bom.Entry, warnings []string, prices jlcpcb.PriceList) error {
+	header, rows := opts.profile.BOMTable(converted)
+	sheets := []spreadsheet.Sheet{{Name: "BOM", Header: header, Rows: spreadsheet.TextRows(rows)}}
+
+	if opts.summary {
+		dnpSheet := spreadsheet.Sheet{
+			Name:   "DNP",
+			Header: []string{"Designator", "Value", "Footprint", "LCSC Part Number"},
+		}
+		for _, entry := range dnp {
+			dnpSheet.Rows = append(dnpSheet.Rows, []any{entry.Reference.String(), entry.Value, entry.Footprint, entry.LCSC})
+		}
+
+		warningsSheet := spreadsheet.Sheet{
+			Name:   "Warnings",
+			Header: []string{"Warning"},
+		}
+		for _, warning := range warnings {
+			warningsSheet.Rows = append(warningsSheet.Rows, []any{warning})
+		}
+
+		sheets = append(sheets, dnpSheet, warningsSheet)
+	}
+
+	if prices != nil {
+		lines, total := jlcpcb.EstimateCost(converted, prices)
+
+		costSheet := spreadsheet.Sheet{
+			Name:   "Cost",
+			Header: []string{"LCSC Part Number", "Designator", "Qty", "Unit Price", "Total"},
+		}
+		for _, line := range lines {
+			if !line.Priced {
+				costSheet.Rows = append(costSheet.Rows, []any{line.LCSC, line.Designator, line.Qty, nil, nil})
+				continue
+			}
+			costSheet.Rows = append(costSheet.Rows, []any{line.LCSC, line.Designator, line.Qty, line.UnitPrice, line.Total})
+		}
+		costSheet.Rows = append(costSheet.Rows, []any{"Total", nil, nil, nil, total})
+
+		sheets = append(sheets, costSheet)
+	}
+
+	return spreadsheet.Write(w, sheets)
+}
+
+// loadBOM loads a BOM (from the given sheet, if it's an xlsx BOM) and applies
+// the policy to it.
+func loadBOM(file, inputFormat, sheet string, policy bomPolicy) ([]bom.Entry, error) {
+	var entries []bom.Entry
+	var err error
+	if sheet != "" {
+		if inputFormat != "" && inputFormat != "xlsx" {
+			return nil, fmt.Errorf("--sheet is only supported with xlsx BOMs (not %s)", inputFormat)
+		}
+
+		inputFormat = "xlsx"
+		entries, err = bom.ReadFile(file, &spreadsheet.BOMReader{Sheet: sheet})
+	} else {
+		entries, inputFormat, err = bom.Load(file, inputFormat)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading BOM: %w", err)
+	}
+
+	if entries, err = policy.apply(entries); err != nil {
+		return nil, err
+	}
+
+	slog.Info("Loaded BOM", slog.String("format", inputFormat), slog.Int("entries", len(entries)))
+
+	return entries, nil
+}
+
+type bomCheckOptions struct {
+	inputFormat string
+	// sheet selects the sheet of an xlsx BOM.
+	sheet  string
+	format string
+	output string
+	policy bomPolicy
+}
+
+// checkBOM checks a BOM (see bom.Check), writing the entries and any warnings.
+// It fails if there are any warnings.
+func checkBOM(file string, opts bomCheckOptions) error {
+	if file == "" {
+		return fmt.Errorf("no BOM file specified")
+	}
+
+	if err := checkReportFormat(opts.format); err != nil {
+		return err
+	}
+
+	entries, err := loadBOM(file, opts.inputFormat, opts.sheet, opts.policy)
+	if err != nil {
+		return err
+	}
+
+	warnings := bom.Check(entries)
+	for _, warning := range warnings {
+		slog.Warn(warning)
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := writeReport(w, opts.format, entries, warnings); err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		return fmt.Errorf("found %d issues in the BOM", len(warnings))
+	}
+
+	slog.Info("No issues found in the BOM")
+
+	return nil
+}
+
+type bomMergeOptions struct {
+	inputFormat string
+	format      string
+	output      string
+	policy      bomPolicy
+}
+
+func mergeBOMs(args []string, opts bomMergeOptions) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no BOM files specified")
+	}
+
+	files := make([]string, len(args))
+	quantities := make([]int, len(args))
+	for i, arg := range args {
+		var err error
+		files[i], quantities[i], err = parseBoardArg(arg)
+		if err != nil {
+			return err
+		}
+	}
+
+	names := boardNames(files)
+
+	var boards []bom.Board
+	for i, file := range files {
+		qty := quantities[i]
+
+		slog.Info("Loading BOM", slog.Any("file", file), slog.Int("qty", qty))
+
+		entries, _, err := bom.Load(file, opts.inputFormat)
+		if err != nil {
+			return fmt.Errorf("error loading BOM %s: %w", file, err)
+		}
+
+		if entries, err = opts.policy.apply(entries); err != nil {
+			return err
+		}
+
+		boards = append(boards, bom.Board{
+			Name:     names[i],
+			Quantity: qty,
+			Entries:  entries,
+		})
+	}
+
+	lines, warnings := bom.Merge(boards)
+	for _, warning := range warnings {
+		slog.Warn(warning)
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	switch opts.format {
+	case "csv":
+		return writePurchaseLinesCSV(w, boards, lines)
+	case "json":
+		return writeJSON(w, lines)
+	case "yaml":
+		return writeYAML(w, lines)
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.format)
+	}
+}
+
+// parseBoardArg parses a "<file>[:qty=<n>]" board argument.
+func parseBoardArg(arg string) (string, int, error) {
+	i := strings.LastIndex(arg, ":qty=")
+	if i < 0 {
+		return arg, 1, nil
+	}
+
+	qty, err := strconv.Atoi(arg[i+len(":qty="):])
+	if err != nil || qty < 1 {
+		return "", 0, fmt.Errorf("invalid board quantity: %s", arg)
+	}
+
+	return arg[:i], qty, nil
+}
+
+// boardNames names boards after their BOM files (without the extension), using
+// the full path if several BOM files have the same name.
+func boardNames(files []string) []string {
+	names := make([]string, len(files))
+	count := make(map[string]int)
+	for i, file := range files {
+		names[i] = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		count[names[i]]++
+	}
+
+	for i, file := range files {
+		if count[names[i]] > 1 {
+			names[i] = strings.TrimSuffix(file, filepath.Ext(file))
+		}
+	}
+
+	return names
+}
+
+func writePurchaseLinesCSV(w io.Writer, boards []bom.Board, lines []bom.PurchaseLine) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	header := []string{"LCSC Part Number", "Value", "Footprint", "Total Qty"}
+	for _, board := range boards {
+		header = append(header, fmt.Sprintf("%s Qty (x%d)", board.Name, board.Quantity))
+	}
+
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+
+	for _, line := range lines {
+		perBoard := make([]int, len(boards))
+		for _, b := range line.Boards {
+			perBoard[b.Index] += b.Qty
+		}
+
+		record := []string{
+			line.LCSC,
+			line.Value,
+			line.Footprint,
+			strconv.Itoa(line.Qty),
+		}
+		for _, qty := range perBoard {
+			record = append(record, strconv.Itoa(qty))
+		}
+
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("error writing record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type bomDiffOptions struct {
+	inputFormat string
+	format      string
+	policy      bomPolicy
+}
+
+func diffBOMs(oldFile, newFile string, opts bomDiffOptions) error {
+	oldEntries, _, err := bom.Load(oldFile, opts.inputFormat)
+	if err != nil {
+		return fmt.Errorf("error loading BOM %s: %w", oldFile, err)
+	}
+
+	if oldEntries, err = opts.policy.apply(oldEntries); err != nil {
+		return err
+	}
+
+	newEntries, _, err := bom.Load(newFile, opts.inputFormat)
+	if err != nil {
+		return fmt.Errorf("error loading BOM %s: %w", newFile, err)
+	}
+
+	if newEntries, err = opts.policy.apply(newEntries); err != nil {
+		return err
+	}
+
+	changes, warnings := bom.Diff(oldEntries, newEntries)
+	for _, warning := range warnings {
+		slog.Warn(warning)
+	}
+
+	switch opts.format {
+	case "text":
+		return writeBOMDiffText(os.Stdout, changes)
+	case "json":
+		return writeJSON(os.Stdout, changes)
+	case "yaml":
+		return writeYAML(os.Stdout, changes)
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.format)
+	}
+}
+
+func writeBOMDiffText(w io.Writer, changes []bom.DesignatorChange) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for _, change := range changes {
+		var err error
+		switch change.Change {
+		case bom.ChangeAdded:
+			_, err = fmt.Fprintf(tw, "+\t%s\t%s\t%s\t%s\n",
+				change.Designator, change.New.Value, change.New.Footprint, change.New.LCSC)
+		case bom.ChangeRemoved:
+			_, err = fmt.Fprintf(tw, "-\t%s\t%s\t%s\t%s\n",
+				change.Designator, change.Old.Value, change.Old.Footprint, change.Old.LCSC)
+		case bom.ChangeChanged:
+			_, err = fmt.Fprintf(tw, "~\t%s\t%s\n", change.Designator, strings.Join(describeFieldChanges(change), ", "))
+		case bom.ChangeDNP:
+			fields := append([]string{fmt.Sprintf("%s -> %s", fittedOrDNP(change.Old.DNP), fittedOrDNP(change.New.DNP))},
+				describeFieldChanges(change)...)
+			_, err = fmt.Fprintf(tw, "!\t%s\t%s\n", change.Designator, strings.Join(fields, ", "))
+		}
+		if err != nil {
+			return fmt.Errorf("error writing diff: %w", err)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// describeFieldChanges describes the value, footprint and LCSC changes of a
+// designator.
+func describeFieldChanges(change bom.DesignatorChange) []string {
+	var fields []string
+	for _, field := range change.Fields {
+		switch field {
+		case "value":
+			fields = append(fields, fmt.Sprintf("value: %s -> %s", change.Old.Value, change.New.Value))
+		case "footprint":
+			fields = append(fields, fmt.Sprintf("footprint: %s -> %s", change.Old.Footprint, change.New.Footprint))
+		case "lcsc":
+			fields = append(fields, fmt.Sprintf("lcsc: %s -> %s", change.Old.LCSC, change.New.LCSC))
+		}
+	}
+
+	return fields
+}
+
+func fittedOrDNP(dnp bool) string {
+	if dnp {
+		return "DNP"
+	}
+	return "fitted"
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBOM(t *testing.T) {
+	dir := t.TempDir()
+
+	bomFile := filepath.Join(dir, "bom.csv")
+	require.NoError(t, os.WriteFile(bomFile, []byte(`"Reference","Value","Footprint","Qty","DNP","LCSC PN"
+"R1,R2","10k","R_0603_1608Metric","3","","C25804"
+"R3","10k","R_0603_1608Metric","1","maybe","C25804"
+`), 0o644))
+
+	output := filepath.Join(dir, "check.json")
+	err := checkBOM(bomFile, bomCheckOptions{format: "json", output: output})
+	require.ErrorContains(t, err, "found 2 issues")
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	var r report[bom.Entry]
+	require.NoError(t, json.Unmarshal(data, &r))
+	assert.Len(t, r.Entries, 2)
+	assert.Equal(t, []string{
+		`entry "R1,R2" has a quantity of 3 but 2 designators`,
+		`entry "R3" has an unknown DNP value "maybe", treating it as populated`,
+	}, r.Warnings)
+}
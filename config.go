@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/config"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/urfave/cli/v2"
+)
+
+const configMetadataKey = "config"
+
+// withProjectConfig arranges for every (leaf) command to load the project
+// configuration file before it runs.
+func withProjectConfig(commands []*cli.Command, parents ...string) {
+	for _, cmd := range commands {
+		path := append(append([]string{}, parents...), cmd.Name)
+
+		if len(cmd.Subcommands) > 0 {
+			withProjectConfig(cmd.Subcommands, path...)
+			continue
+		}
+
+		cmd.Before = func(c *cli.Context) error {
+			return loadProjectConfig(c, path)
+		}
+	}
+}
+
+// loadProjectConfig finds the project configuration file (by walking up from
+// the first input file) and uses it to supply defaults for any flags that were
+// not set on the command line.
+func loadProjectConfig(c *cli.Context, command []string) error {
+	path := c.Path("config")
+	if path == "" {
+		dir := "."
+		if c.NArg() > 0 {
+			// Strip any board quantity suffix (see parseBoardArg).
+			file, _, _ := strings.Cut(c.Args().First(), ":qty=")
+			dir = filepath.Dir(file)
+		}
+
+		var err error
+		path, err = config.Find(dir)
+		if err != nil {
+			return fmt.Errorf("error finding project configuration: %w", err)
+		}
+
+		if path == "" {
+			return nil
+		}
+	}
+
+	slog.Info("Using project configuration", slog.String("file", path))
+
+	conf, err := config.Load(path)
+	if err != nil {
+		return fmt.Errorf("error loading project configuration: %w", err)
+	}
+
+	c.App.Metadata[configMetadataKey] = conf
+
+	defaults := conf.Defaults(command)
+
+	for _, flag := range c.Command.Flags {
+		name := flag.Names()[0]
+
+		value, ok := defaults[name]
+		if !ok || c.IsSet(name) {
+			continue
+		}
+
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+
+		for _, v := range values {
+			s := fmt.Sprint(v)
+
+			// Relative paths are relative to the configuration file.
+			if _, isPath := flag.(*cli.PathFlag); isPath && !filepath.IsAbs(s) {
+				s = filepath.Join(conf.Dir(), s)
+			}
+
+			if err := c.Set(name, s); err != nil {
+				return fmt.Errorf("invalid value for %s in project configuration: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// bomPolicy is how BOMs are read, from the project configuration.
+type bomPolicy struct {
+	// fieldAliases are the columns to read each BOM field from (see
+	// bom.ApplyFieldAliases).
+	fieldAliases map[string][]string
+	// includeDNP treats parts marked DNP as fitted.
+	includeDNP bool
+}
+
+func newBOMPolicy(conf *config.Config) bomPolicy {
+	return bomPolicy{
+		fieldAliases: conf.FieldAliases,
+		includeDNP:   conf.DNP == config.DNPInclude,
+	}
+}
+
+// apply applies the policy to loaded BOM entries.
+func (p bomPolicy) apply(entries []bom.Entry) ([]bom.Entry, error) {
+	entries, err := bom.ApplyFieldAliases(entries, p.fieldAliases)
+	if err != nil {
+		return nil, fmt.Errorf("error applying field aliases: %w", err)
+	}
+
+	if p.includeDNP {
+		for i := range entries {
+			if entries[i].DNP == bom.DoNotPopulate {
+				entries[i].DNP = bom.Populate
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// projectConfig returns the loaded project configuration (if any).
+func projectConfig(c *cli.Context) *config.Config {
+	conf, ok := c.App.Metadata[configMetadataKey].(*config.Config)
+	if !ok {
+		return &config.Config{}
+	}
+
+	return conf
+}
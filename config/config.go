@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"gopkg.in/yaml.v3"
+)
+
+// DNP policies.
+const (
+	// DNPExclude leaves parts marked DNP out of converted and merged BOMs, and
+	// (given a BOM) out of converted component placements.
+	DNPExclude = "exclude"
+	// DNPInclude treats parts marked DNP as fitted.
+	DNPInclude = "include"
+)
+
+// FileNames are the names of project configuration files, in order of preference.
+var FileNames = []string{"jlcfabtool.yaml", "jlcfabtool.yml", "jlcfabtool.toml"}
+
+// Config is a per-project (board) configuration file.
+type Config struct {
+	// Path is the file the configuration was loaded from.
+	Path string `yaml:"-" toml:"-"`
+	// Commands holds default flag values for each subcommand, keyed by the
+	// command path (eg. commands.bom.convert.format).
+	Commands map[string]any `yaml:"commands" toml:"commands"`
+	// Rotations are additional rotation corrections, they take precedence over
+	// the built-in rules.
+	Rotations jlcpcb.RotationTable `yaml:"rotations" toml:"rotations"`
+	// Footprints are additional footprint mappings, they take precedence over
+	// the built-in mappings.
+	Footprints jlcpcb.FootprintTable `yaml:"footprints" toml:"footprints"`
+	// Profiles are additional assembly profiles (see the --profile flag).
+	Profiles []assembly.Profile `yaml:"profiles" toml:"profiles"`
+	// Origin is the origin of the board in the component placement files'
+	// coordinates (eg. if placements were exported relative to the page), it is
+	// subtracted from every placement.
+	Origin *Origin `yaml:"origin" toml:"origin"`
+	// DNP is the policy for parts marked DNP (DNPExclude or DNPInclude, defaults
+	// to DNPExclude).
+	DNP string `yaml:"dnp" toml:"dnp"`
+	// FieldAliases are the names of the BOM columns to read each field from,
+	// for BOMs that don't use KiCad's column names (see bom.ApplyFieldAliases).
+	FieldAliases map[string][]string `yaml:"field_aliases" toml:"field_aliases"`
+}
+
+// Origin is a point on the board (in millimetres).
+type Origin struct {
+	X float64 `yaml:"x" toml:"x"`
+	Y float64 `yaml:"y" toml:"y"`
+}
+
+// Find searches for a project configuration file in dir and each of its
+// parents. If no configuration file is found, an empty path is returned.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve directory: %w", err)
+	}
+
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("could not stat file: %w", err)
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load loads a project configuration file (YAML or TOML, based on the extension).
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	conf := Config{Path: path}
+
+	switch filepath.Ext(path) {
+	case ".toml":
+		if err := toml.Unmarshal(data, &conf); err != nil {
+			return nil, fmt.Errorf("could not parse TOML: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &conf); err != nil {
+			return nil, fmt.Errorf("could not parse YAML: %w", err)
+		}
+	}
+
+	for _, p := range conf.Profiles {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch conf.DNP {
+	case "", DNPExclude, DNPInclude:
+	default:
+		return nil, fmt.Errorf("unknown DNP policy: %s", conf.DNP)
+	}
+
+	if _, err := bom.ApplyFieldAliases(nil, conf.FieldAliases); err != nil {
+		return nil, fmt.Errorf("invalid field aliases: %w", err)
+	}
+
+	return &conf, nil
+}
+
+// Defaults returns the default flag values for a command (eg. ["bom", "convert"]).
+func (c *Config) Defaults(command []string) map[string]any {
+	defaults := c.Commands
+	for _, name := range command {
+		next, ok := defaults[name].(map[string]any)
+		if !ok {
+			return nil
+		}
+		defaults = next
+	}
+
+	return defaults
+}
+
+// Dir returns the directory containing the configuration file, relative paths
+// in the configuration are resolved against it.
+func (c *Config) Dir() string {
+	return filepath.Dir(c.Path)
+}
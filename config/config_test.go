@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	path, err := config.Find(filepath.Join("testdata", "board", "gerbers"))
+	require.NoError(t, err)
+
+	expected, err := filepath.Abs(filepath.Join("testdata", "board", "jlcfabtool.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, path)
+
+	path, err = config.Find("testdata")
+	require.NoError(t, err)
+
+	assert.Equal(t, "jlcfabtool.toml", filepath.Base(path))
+}
+
+func TestLoadYAML(t *testing.T) {
+	conf, err := config.Load(filepath.Join("testdata", "board", "jlcfabtool.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"consolidate":   true,
+		"footprint-map": "footprints.csv",
+	}, conf.Defaults([]string{"bom", "convert"}))
+	assert.Equal(t, "json", conf.Defaults([]string{"placement", "convert"})["format"])
+	assert.Nil(t, conf.Defaults([]string{"placement", "diff"}))
+
+	require.Len(t, conf.Rotations, 2)
+	assert.Equal(t, "^Custom_QFN-24$", conf.Rotations[0].PackagePattern.String())
+	assert.Equal(t, -90.0, conf.Rotations[0].Rotation)
+	require.NotNil(t, conf.Rotations[1].CenterX)
+	assert.Equal(t, 3.81, *conf.Rotations[1].CenterX)
+	assert.Nil(t, conf.Rotations[1].CenterY)
+
+	require.Len(t, conf.Footprints, 1)
+	assert.Equal(t, "QFN-24-EP(4x4)", conf.Footprints[0].Package)
+
+	require.Len(t, conf.Profiles, 1)
+	assert.Equal(t, "acme", conf.Profiles[0].Name)
+	assert.Equal(t, "part_number", conf.Profiles[0].BOMColumns[0].Field)
+
+	assert.Equal(t, &config.Origin{X: 100, Y: -50}, conf.Origin)
+	assert.Equal(t, config.DNPInclude, conf.DNP)
+	assert.Equal(t, map[string][]string{
+		"lcsc": {"JLCPCB Part #", "LCSC"},
+		"qty":  {"Quantity"},
+	}, conf.FieldAliases)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"dnp":           "dnp: sometimes\n",
+		"field_aliases": "field_aliases:\n  mpn: [\"Part Number\"]\n",
+	} {
+		path := filepath.Join(t.TempDir(), "jlcfabtool.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		_, err := config.Load(path)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	conf, err := config.Load(filepath.Join("testdata", "jlcfabtool.toml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "yaml", conf.Defaults([]string{"placement", "convert"})["format"])
+
+	require.Len(t, conf.Rotations, 1)
+	assert.Equal(t, "^Conn_1x04$", conf.Rotations[0].PackagePattern.String())
+	assert.Equal(t, 90.0, conf.Rotations[0].Rotation)
+	require.NotNil(t, conf.Rotations[0].CenterX)
+	assert.Equal(t, 3.81, *conf.Rotations[0].CenterX)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// unmarshalBaseline is the implementation of csvx.Unmarshal that preceded
+// Decoder (reduced to the field types used by the benchmarks), kept as a
+// baseline for BenchmarkUnmarshal. The struct tags are looked up for every row
+// and every record is allocated.
+func unmarshalBaseline[T any](r io.Reader) ([]T, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	headerMap := make(map[string]int)
+	for i, h := range headers {
+		headerMap[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	var results []T
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row: %w", err)
+		}
+
+		var item T
+		if err := unmarshalBaselineRecord(reflect.ValueOf(&item).Elem(), headerMap, record); err != nil {
+			return nil, err
+		}
+
+		results = append(results, item)
+	}
+
+	return results, nil
+}
+
+func unmarshalBaselineRecord(itemVal reflect.Value, headerMap map[string]int, record []string) error {
+	itemType := itemVal.Type()
+
+	for i := 0; i < itemVal.NumField(); i++ {
+		field := itemVal.Field(i)
+		fieldType := itemType.Field(i)
+
+		tag := fieldType.Tag.Get("csv")
+		if tag == "" {
+			continue
+		}
+
+		colIdx, exists := headerMap[strings.ToLower(tag)]
+		if !exists {
+			continue
+		}
+
+		var rawValue string
+		if colIdx < len(record) {
+			rawValue = record[colIdx]
+		}
+
+		if field.CanAddr() {
+			if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+				if err := unmarshaler.UnmarshalText([]byte(rawValue)); err != nil {
+					return fmt.Errorf("failed to unmarshal field %s: %w", fieldType.Name, err)
+				}
+				continue
+			}
+		}
+
+		if rawValue == "" {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(rawValue)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			intValue, err := strconv.ParseInt(rawValue, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid int for field %s: %s", fieldType.Name, rawValue)
+			}
+			field.SetInt(intValue)
+		case reflect.Float32, reflect.Float64:
+			floatValue, err := strconv.ParseFloat(rawValue, 64)
+			if err != nil {
+				return fmt.Errorf("invalid float for field %s: %s", fieldType.Name, rawValue)
+			}
+			field.SetFloat(floatValue)
+		default:
+			return fmt.Errorf("unsupported field type %s for field %s", field.Kind(), fieldType.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,376 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"bufio"
+	"encoding"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decoder reads structs from a CSV stream one row at a time. The first row is
+// the header (see WithSkipLines and WithHeader), columns are matched to fields
+// by their csv tags (ignoring case). The encoding, delimiter and decimal
+// separator are detected from the start of the stream (unless they're set with
+// an Option).
+type Decoder[T any] struct {
+	text    *bufio.Reader
+	opts    options
+	dialect Dialect
+	reader  recordReader
+	columns *columnMap
+	header  []string
+	err     error
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder[T any](r io.Reader, opts ...Option) *Decoder[T] {
+	o := newOptions(opts)
+
+	dialect, text := newDialectReader(r, o)
+
+	return &Decoder[T]{text: text, opts: o, dialect: dialect}
+}
+
+// Dialect returns the (detected) dialect of the stream.
+func (d *Decoder[T]) Dialect() Dialect {
+	return d.dialect
+}
+
+// Header returns the header row (reading it if needed).
+func (d *Decoder[T]) Header() ([]string, error) {
+	if d.header != nil || d.err != nil {
+		return d.header, d.err
+	}
+
+	header, err := d.readHeader()
+	if err != nil {
+		d.err = fmt.Errorf("failed to read CSV header: %w", err)
+		return nil, d.err
+	}
+
+	columns, err := mapColumns[T](header)
+	if err != nil {
+		d.err = err
+		return nil, d.err
+	}
+
+	columns.decimalSeparator = d.dialect.DecimalSeparator
+	d.header, d.columns = header, columns
+
+	return d.header, nil
+}
+
+// readHeader skips the preamble (if any) and reads the header row.
+func (d *Decoder[T]) readHeader() ([]string, error) {
+	var lines int
+	for ; lines < d.opts.skipLines; lines++ {
+		if _, err := readLine(d.text); err != nil {
+			return nil, err
+		}
+	}
+
+	var header []string
+	if d.opts.isHeader != nil {
+		for header == nil {
+			line, err := readLine(d.text)
+			if err == io.EOF {
+				return nil, fmt.Errorf("could not find header row")
+			}
+			if err != nil {
+				return nil, err
+			}
+			lines++
+
+			// Headers may be commented out (eg. "# Ref Val Package").
+			if d.opts.comment != 0 {
+				line = strings.TrimLeft(strings.TrimSpace(line), string(d.opts.comment))
+			}
+
+			if record := splitRecord(line, d.dialect.Delimiter); record != nil && d.opts.isHeader(record) {
+				header = record
+			}
+		}
+	}
+
+	if d.dialect.Delimiter == Whitespace {
+		d.reader = &whitespaceRecords{reader: d.text, comment: d.opts.comment, line: lines}
+	} else {
+		records := newCSVRecords(d.text, d.dialect, d.opts.comment, lines)
+		if header != nil {
+			records.reader.FieldsPerRecord = len(header)
+		}
+		d.reader = records
+	}
+
+	if header == nil {
+		record, err := d.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		// The record may be reused by the reader, so take a copy.
+		header = append([]string(nil), record...)
+	}
+
+	return header, nil
+}
+
+// Decode reads the next row into v (which is zeroed first). It returns io.EOF
+// when there are no more rows.
+func (d *Decoder[T]) Decode(v *T) error {
+	if _, err := d.Header(); err != nil {
+		return err
+	}
+
+	record, err := d.reader.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read CSV row: %w", err)
+	}
+
+	var zero T
+	*v = zero
+
+	if err := d.columns.decodeRecord(reflect.ValueOf(v).Elem(), record); err != nil {
+		return fmt.Errorf("line %d: %w", d.Line(), err)
+	}
+
+	return nil
+}
+
+// Record reads the next row without decoding it (eg. for files with a variable
+// schema, see Header). It returns io.EOF when there are no more rows.
+func (d *Decoder[T]) Record() ([]string, error) {
+	if _, err := d.Header(); err != nil {
+		return nil, err
+	}
+
+	record, err := d.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV row: %w", err)
+	}
+
+	// The record is reused by the reader, so take a copy.
+	return append([]string(nil), record...), nil
+}
+
+// Line returns the line number of the most recently read row.
+func (d *Decoder[T]) Line() int {
+	if d.reader == nil {
+		return 0
+	}
+
+	return d.reader.Line()
+}
+
+// All returns an iterator over the remaining rows. Iteration stops after the
+// first error.
+func (d *Decoder[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			var item T
+			err := d.Decode(&item)
+			if err == io.EOF {
+				return
+			}
+
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// columnMap maps the csv tagged fields of a struct type onto the columns of a
+// header.
+type columnMap struct {
+	*typeFields
+	header []string
+	// columns holds the column index of each field (or -1 if the column is
+	// missing).
+	columns []int
+	// unbound holds the indices of the columns that aren't bound to a field
+	// (collected by the extra field, if any).
+	unbound []int
+	// decimalSeparator is the decimal separator of floating point fields
+	// (defaults to '.').
+	decimalSeparator rune
+}
+
+// mapColumns maps the csv tagged fields of T onto the columns of a header.
+func mapColumns[T any](header []string) (*columnMap, error) {
+	tf, err := cachedFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize headers to lowercase for case-insensitive matching
+	headerMap := make(map[string]int, len(header))
+	for i, h := range header {
+		headerMap[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+
+	m := &columnMap{
+		typeFields: tf,
+		header:     header,
+		columns:    make([]int, len(tf.fields)),
+	}
+
+	bound := make([]bool, len(header))
+	for i, f := range tf.fields {
+		col, ok := headerMap[f.column]
+		if !ok {
+			col = -1 // Skip if column is not found
+		} else {
+			bound[col] = true
+		}
+		m.columns[i] = col
+	}
+
+	if tf.extra != nil {
+		for i, h := range header {
+			if !bound[i] && strings.TrimSpace(h) != "" {
+				m.unbound = append(m.unbound, i)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// decodeRecord sets the csv tagged fields of itemVal from a record.
+func (m *columnMap) decodeRecord(itemVal reflect.Value, record []string) error {
+	for i, f := range m.fields {
+		var rawValue string
+		if colIdx := m.columns[i]; colIdx >= 0 && colIdx < len(record) {
+			rawValue = record[colIdx]
+		} else if colIdx < 0 && !f.hasDefault {
+			continue // Skip if column is not found
+		}
+
+		if rawValue == "" && f.hasDefault {
+			rawValue = f.defaultValue
+		}
+
+		fieldVal := fieldForWrite(itemVal, f.index)
+
+		if f.pointer {
+			// Empty cells leave pointers nil.
+			if rawValue == "" {
+				continue
+			}
+
+			fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+			fieldVal = fieldVal.Elem()
+		}
+
+		if m.decimalSeparator == ',' {
+			switch fieldVal.Kind() {
+			case reflect.Float32, reflect.Float64:
+				rawValue = strings.Replace(rawValue, ",", ".", 1)
+			}
+		}
+
+		if err := decodeValue(fieldVal, f, rawValue); err != nil {
+			return err
+		}
+	}
+
+	// Collect the non-empty unbound cells.
+	if m.extra != nil {
+		var extra map[string]string
+		for _, colIdx := range m.unbound {
+			if colIdx >= len(record) || record[colIdx] == "" {
+				continue
+			}
+
+			if extra == nil {
+				extra = make(map[string]string)
+			}
+			extra[strings.TrimSpace(m.header[colIdx])] = record[colIdx]
+		}
+
+		if extra != nil {
+			fieldForWrite(itemVal, m.extra.index).Set(reflect.ValueOf(extra))
+		}
+	}
+
+	return nil
+}
+
+// decodeValue sets a (non-pointer) field from a raw cell value.
+func decodeValue(fieldVal reflect.Value, f field, rawValue string) error {
+	// If field implements encoding.TextUnmarshaler, use it
+	if f.textUnmarshaler {
+		unmarshaler := fieldVal.Addr().Interface().(encoding.TextUnmarshaler)
+		if err := unmarshaler.UnmarshalText([]byte(rawValue)); err != nil {
+			return fmt.Errorf("failed to unmarshal field %s: %w", f.name, err)
+		}
+		return nil
+	}
+
+	// Is the field empty?
+	if rawValue == "" {
+		return nil
+	}
+
+	// Convert to primitive types
+	switch fieldVal.Kind() {
+	case reflect.String:
+		fieldVal.SetString(rawValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if intValue, err := strconv.ParseInt(rawValue, 10, 64); err == nil {
+			fieldVal.SetInt(intValue)
+		} else {
+			return fmt.Errorf("invalid int for field %s: %s", f.name, rawValue)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if uintValue, err := strconv.ParseUint(rawValue, 10, 64); err == nil {
+			fieldVal.SetUint(uintValue)
+		} else {
+			return fmt.Errorf("invalid uint for field %s: %s", f.name, rawValue)
+		}
+	case reflect.Float32, reflect.Float64:
+		if floatValue, err := strconv.ParseFloat(rawValue, 64); err == nil {
+			fieldVal.SetFloat(floatValue)
+		} else {
+			return fmt.Errorf("invalid float for field %s: %s", f.name, rawValue)
+		}
+	case reflect.Bool:
+		if boolValue, err := strconv.ParseBool(strings.ToLower(rawValue)); err == nil {
+			fieldVal.SetBool(boolValue)
+		} else {
+			return fmt.Errorf("invalid bool for field %s: %s", f.name, rawValue)
+		}
+	default:
+		return fmt.Errorf("unsupported field type: %s", f.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder(t *testing.T) {
+	dec := csvx.NewDecoder[Person](strings.NewReader(`Name,Age,Active
+# A comment
+John Doe,30,true
+Jane Smith,,false
+`), csvx.WithComment('#'))
+
+	header, err := dec.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Age", "Active"}, header)
+
+	var p Person
+	require.NoError(t, dec.Decode(&p))
+	assert.Equal(t, "John Doe", p.Name)
+	assert.Equal(t, 30, p.Age)
+	assert.Equal(t, 3, dec.Line())
+
+	// The struct is zeroed before decoding each row.
+	require.NoError(t, dec.Decode(&p))
+	assert.Equal(t, "Jane Smith", p.Name)
+	assert.Equal(t, 0, p.Age)
+	assert.False(t, p.Active)
+
+	assert.Equal(t, io.EOF, dec.Decode(&p))
+}
+
+func TestDecoderAll(t *testing.T) {
+	dec := csvx.NewDecoder[Person](strings.NewReader(`Name,Age
+John Doe,30
+Jane Smith,twenty five
+Bob,40
+`))
+
+	var names []string
+	var errs []error
+	for p, err := range dec.All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, p.Name)
+	}
+
+	// Iteration stops at the first error.
+	assert.Equal(t, []string{"John Doe"}, names)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "line 3")
+}
+
+func TestDecoderEmpty(t *testing.T) {
+	dec := csvx.NewDecoder[Person](strings.NewReader(""))
+
+	var p Person
+	err := dec.Decode(&p)
+	require.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+}
+
+// part is a (cut down) row from the JLCPCB parts library.
+type part struct {
+	LCSC        string  `csv:"LCSC Part"`
+	Category    string  `csv:"First Category"`
+	Package     string  `csv:"Package"`
+	SolderJoint int     `csv:"Solder Joint"`
+	Description string  `csv:"Description"`
+	Price       float64 `csv:"Price"`
+	Stock       int     `csv:"Stock"`
+}
+
+func partsCSV(rows int) string {
+	var sb strings.Builder
+	sb.WriteString("LCSC Part,First Category,Second Category,Package,Solder Joint,Manufacturer,Library Type,Description,Datasheet,Price,Stock\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&sb, "C%d,Resistors,Chip Resistor - Surface Mount,0603,2,UNI-ROYAL,Basic,10k 1%% 100mW,https://example.com/C%d.pdf,0.0005,%d\n", i, i, i*10)
+	}
+	return sb.String()
+}
+
+// BenchmarkUnmarshal compares decoding with the implementation that preceded
+// Decoder (see unmarshalBaseline), csvx.Unmarshal and streaming with a Decoder.
+// Allocations are reported for each, eg.
+//
+//	go test ./csvx -run '^$' -bench Unmarshal
+func BenchmarkUnmarshal(b *testing.B) {
+	const rows = 10000
+	data := partsCSV(rows)
+
+	b.Run("baseline", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			parts, err := unmarshalBaseline[part](strings.NewReader(data))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(parts) != rows {
+				b.Fatalf("unexpected number of parts: %d", len(parts))
+			}
+		}
+	})
+
+	b.Run("unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			parts, err := csvx.Unmarshal[part](strings.NewReader(data))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(parts) != rows {
+				b.Fatalf("unexpected number of parts: %d", len(parts))
+			}
+		}
+	})
+
+	b.Run("decoder", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			dec := csvx.NewDecoder[part](strings.NewReader(data))
+
+			var n int
+			var p part
+			for {
+				err := dec.Decode(&p)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+				n++
+			}
+			if n != rows {
+				b.Fatalf("unexpected number of parts: %d", n)
+			}
+		}
+	})
+}
+
+func TestUnmarshalBaseline(t *testing.T) {
+	// The baseline must decode the benchmark data in the same way.
+	data := partsCSV(10)
+
+	expected, err := csvx.Unmarshal[part](strings.NewReader(data))
+	require.NoError(t, err)
+
+	parts, err := unmarshalBaseline[part](strings.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, parts)
+}
+
+func TestDecoderRecord(t *testing.T) {
+	dec := csvx.NewDecoder[struct{}](strings.NewReader("a,b\n# comment\n1,2\n3,4\n"), csvx.WithComment('#'))
+
+	header, err := dec.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, header)
+	assert.Equal(t, 1, dec.Line())
+
+	record, err := dec.Record()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, record)
+	assert.Equal(t, 3, dec.Line())
+
+	record, err = dec.Record()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "4"}, record)
+
+	_, err = dec.Record()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestSplitFields(t *testing.T) {
+	fields, err := csvx.SplitFields(`  R1  "10k 1%"	R_0603 `)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"R1", "10k 1%", "R_0603"}, fields)
+
+	_, err = csvx.SplitFields(`R1 "10k`)
+	require.Error(t, err)
+}
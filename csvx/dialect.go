@@ -0,0 +1,246 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// sniffLen is how much of a file is used to detect its dialect.
+const sniffLen = 8192
+
+// Dialect describes how a CSV file was written. Files saved by spreadsheet
+// applications often differ from plain comma separated UTF-8, depending on the
+// application and its locale.
+type Dialect struct {
+	// Encoding is the character encoding (nil for UTF-8).
+	Encoding encoding.Encoding
+	// Delimiter is the field delimiter (eg. ',', ';', '\t' or Whitespace).
+	Delimiter rune
+	// DecimalSeparator is the decimal separator of numbers ('.' or ',').
+	DecimalSeparator rune
+}
+
+// delimiters are the candidate field delimiters, in order of preference.
+var delimiters = []rune{',', ';', '\t', '|'}
+
+// newDialectReader detects any part of the dialect that isn't set in the
+// options. It returns the dialect and a reader of the (UTF-8) text.
+func newDialectReader(r io.Reader, o options) (Dialect, *bufio.Reader) {
+	dialect := o.Dialect
+
+	br := bufio.NewReaderSize(r, sniffLen)
+
+	if dialect.Encoding == nil {
+		head, _ := br.Peek(sniffLen)
+		dialect.Encoding = detectEncoding(head)
+	}
+
+	// A byte order mark overrides the encoding (and is removed).
+	var decoder transform.Transformer = transform.Nop
+	if dialect.Encoding != nil {
+		decoder = dialect.Encoding.NewDecoder()
+	}
+	text := bufio.NewReaderSize(transform.NewReader(br, unicode.BOMOverride(decoder)), sniffLen)
+
+	if dialect.Delimiter != 0 && dialect.DecimalSeparator != 0 {
+		return dialect, text
+	}
+
+	head, _ := text.Peek(sniffLen)
+	lines := sampleLines(head, o)
+
+	if dialect.Delimiter == 0 {
+		dialect.Delimiter = detectDelimiter(lines)
+	}
+	if dialect.DecimalSeparator == 0 {
+		dialect.DecimalSeparator = detectDecimalSeparator(lines, dialect.Delimiter)
+	}
+
+	return dialect, text
+}
+
+// detectEncoding detects UTF-16 text (with or without a byte order mark). It
+// returns nil for anything else, which is treated as UTF-8.
+func detectEncoding(head []byte) encoding.Encoding {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xff, 0xfe}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM)
+	case bytes.HasPrefix(head, []byte{0xfe, 0xff}):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM)
+	}
+
+	// Without a byte order mark, mostly ASCII UTF-16 text has a NUL byte in
+	// every other position.
+	if len(head) > 64 {
+		head = head[:64]
+	}
+	if len(head) < 4 {
+		return nil
+	}
+
+	var evenNULs, oddNULs int
+	for i, b := range head {
+		if b != 0 {
+			continue
+		}
+
+		if i%2 == 0 {
+			evenNULs++
+		} else {
+			oddNULs++
+		}
+	}
+
+	pairs := len(head) / 2
+	switch {
+	case oddNULs >= pairs*9/10 && evenNULs == 0:
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
+	case evenNULs >= pairs*9/10 && oddNULs == 0:
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)
+	}
+
+	return nil
+}
+
+// sampleLines splits the start of a file into complete lines, skipping the
+// leading lines, comments and blank lines.
+func sampleLines(head []byte, o options) []string {
+	text := string(head)
+
+	// The last line is probably incomplete.
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 && len(head) == sniffLen {
+		text = text[:i]
+	}
+
+	var lines []string
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if i < o.skipLines || strings.TrimSpace(line) == "" || isComment(line, o.comment) {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// detectDelimiter picks the delimiter that splits the most lines into the same
+// number of columns (which tolerates a preamble), preferring more columns if
+// several delimiters are equally consistent.
+func detectDelimiter(lines []string) rune {
+	best, bestScore := ',', 0
+	for _, delimiter := range delimiters {
+		frequency := make(map[int]int)
+		for _, line := range lines {
+			if columns := len(splitRecord(line, delimiter)); columns >= 2 {
+				frequency[columns]++
+			}
+		}
+
+		for columns, count := range frequency {
+			if score := count*1000 + columns; score > bestScore {
+				best, bestScore = delimiter, score
+			}
+		}
+	}
+
+	return best
+}
+
+// splitRecord splits a single line into fields, or returns nil if it can't be
+// parsed on its own (eg. it has a quoted line break).
+func splitRecord(line string, delimiter rune) []string {
+	if delimiter == Whitespace {
+		record, err := SplitFields(line)
+		if err != nil {
+			return nil
+		}
+		return record
+	}
+
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = delimiter
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	record, err := reader.Read()
+	if err != nil {
+		return nil
+	}
+
+	return record
+}
+
+var (
+	decimalPoint = regexp.MustCompile(`^[-+]?\d*\.\d+$`)
+	decimalComma = regexp.MustCompile(`^[-+]?\d*,\d+$`)
+)
+
+// detectDecimalSeparator checks whether numbers are written with a decimal
+// comma. A decimal comma is only possible if the delimiter isn't a comma.
+func detectDecimalSeparator(lines []string, delimiter rune) rune {
+	if delimiter == ',' || len(lines) < 2 {
+		return '.'
+	}
+
+	var points, commas int
+	for _, line := range lines[1:] {
+		for _, value := range splitRecord(line, delimiter) {
+			value = strings.TrimSpace(value)
+			switch {
+			case decimalPoint.MatchString(value):
+				points++
+			case decimalComma.MatchString(value):
+				commas++
+			}
+		}
+	}
+
+	if commas > 0 && points == 0 {
+		return ','
+	}
+
+	return '.'
+}
+
+// DecodeText converts the start of a file to UTF-8 (removing any byte order
+// mark), for readers that sniff the header of CSV files.
+func DecodeText(head []byte) []byte {
+	enc := detectEncoding(head)
+	if enc == nil {
+		return bytes.TrimPrefix(head, []byte("\ufeff"))
+	}
+
+	text, _, err := transform.Bytes(unicode.BOMOverride(enc.NewDecoder()), head)
+	if err != nil && len(text) == 0 {
+		return head
+	}
+
+	return text
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+)
+
+type Coordinate struct {
+	Ref  string  `csv:"Ref"`
+	PosX float64 `csv:"PosX"`
+	PosY float64 `csv:"PosY"`
+}
+
+var coordinates = []Coordinate{
+	{Ref: "C1", PosX: 10.5, PosY: -20.25},
+	{Ref: "R1", PosX: 3, PosY: 4},
+}
+
+func encodeUTF16LE(text string, bom bool) []byte {
+	var buf bytes.Buffer
+	if bom {
+		buf.Write([]byte{0xff, 0xfe})
+	}
+	for _, u := range utf16.Encode([]rune(text)) {
+		buf.WriteByte(byte(u))
+		buf.WriteByte(byte(u >> 8))
+	}
+	return buf.Bytes()
+}
+
+func TestUnmarshalDialects(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		dialect csvx.Dialect
+	}{
+		{
+			name:    "UTF-8 BOM",
+			data:    []byte("\ufeffRef,PosX,PosY\nC1,10.5,-20.25\nR1,3,4\n"),
+			dialect: csvx.Dialect{Delimiter: ',', DecimalSeparator: '.'},
+		},
+		{
+			name:    "Semicolons and decimal commas",
+			data:    []byte("Ref;PosX;PosY\r\nC1;10,5;-20,25\r\nR1;3;4\r\n"),
+			dialect: csvx.Dialect{Delimiter: ';', DecimalSeparator: ','},
+		},
+		{
+			name:    "Semicolons and decimal points",
+			data:    []byte("\"Ref\";\"PosX\";\"PosY\"\nC1;10.5;-20.25\nR1;3;4\n"),
+			dialect: csvx.Dialect{Delimiter: ';', DecimalSeparator: '.'},
+		},
+		{
+			name:    "Tabs",
+			data:    []byte("Ref\tPosX\tPosY\nC1\t10.5\t-20.25\nR1\t3\t4\n"),
+			dialect: csvx.Dialect{Delimiter: '\t', DecimalSeparator: '.'},
+		},
+		{
+			name: "UTF-16 with BOM",
+			data: encodeUTF16LE("Ref\tPosX\tPosY\r\nC1\t10,5\t-20,25\r\nR1\t3\t4\r\n", true),
+			dialect: csvx.Dialect{
+				Encoding:         unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM),
+				Delimiter:        '\t',
+				DecimalSeparator: ',',
+			},
+		},
+		{
+			name: "UTF-16 without BOM",
+			data: encodeUTF16LE("Ref,PosX,PosY\nC1,10.5,-20.25\nR1,3,4\n", false),
+			dialect: csvx.Dialect{
+				Encoding:         unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+				Delimiter:        ',',
+				DecimalSeparator: '.',
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := csvx.NewDecoder[Coordinate](bytes.NewReader(tt.data))
+			assert.Equal(t, tt.dialect, dec.Dialect())
+
+			var rows []Coordinate
+			for row, err := range dec.All() {
+				require.NoError(t, err)
+				rows = append(rows, row)
+			}
+
+			assert.Equal(t, coordinates, rows)
+		})
+	}
+}
+
+func TestUnmarshalDialectOptions(t *testing.T) {
+	// Too little data to detect the decimal comma.
+	rows, err := csvx.Unmarshal[Coordinate](strings.NewReader("Ref|PosX|PosY\nC1|10,5|-20,25\nR1|3|4\n"),
+		csvx.WithDelimiter('|'), csvx.WithDecimalSeparator(','))
+	require.NoError(t, err)
+
+	assert.Equal(t, coordinates, rows)
+
+	_, err = csvx.Unmarshal[Coordinate](strings.NewReader("Ref;PosX;PosY\nC1;10,5;-20,25\n"),
+		csvx.WithDecimalSeparator('.'))
+	assert.Error(t, err)
+}
+
+func TestDecodeText(t *testing.T) {
+	assert.Equal(t, "Ref,PosX", string(csvx.DecodeText([]byte("\ufeffRef,PosX"))))
+	assert.Equal(t, "Ref,PosX", string(csvx.DecodeText(encodeUTF16LE("Ref,PosX", true))))
+	assert.Equal(t, "Ref,PosX", string(csvx.DecodeText([]byte("Ref,PosX"))))
+}
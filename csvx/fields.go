@@ -0,0 +1,251 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// field is a csv tagged struct field. Fields of embedded structs (and of struct
+// fields tagged "inline") are flattened into their parent.
+//
+// The tag is the column name, optionally followed by comma separated options:
+//
+//	omitempty      write zero values as an empty cell
+//	default=value  use value if the cell is empty or the column is missing (must
+//	               be the last option)
+//	inline         flatten the fields of a (named) struct field
+//	extra          collect the columns that aren't bound to any other field, the
+//	               field must be a map[string]string (eg. `csv:",extra"`)
+type field struct {
+	// index is the index sequence (see reflect.Value.FieldByIndex).
+	index []int
+	name  string
+	// header is the column name, and column is its lower case form.
+	header string
+	column string
+	// omitEmpty writes zero values as an empty cell.
+	omitEmpty bool
+	// defaultValue is used for empty cells (if hasDefault is set).
+	defaultValue string
+	hasDefault   bool
+	// pointer is set if the field is a pointer (empty cells leave it nil).
+	pointer bool
+	// textUnmarshaler is set if a pointer to the field's (element) type
+	// implements encoding.TextUnmarshaler.
+	textUnmarshaler bool
+	// extra is set if the field collects the unbound columns.
+	extra bool
+}
+
+// typeFields are the csv tagged fields of a struct type.
+type typeFields struct {
+	fields []field
+	// extra is the field tagged "extra" (if any).
+	extra *field
+}
+
+// tagOptions are the parsed options of a csv struct tag.
+type tagOptions struct {
+	omitEmpty    bool
+	inline       bool
+	extra        bool
+	defaultValue string
+	hasDefault   bool
+}
+
+// parseTag splits a csv struct tag into the column name and its options.
+func parseTag(tag string) (string, tagOptions) {
+	var opts tagOptions
+
+	name, rest, _ := strings.Cut(tag, ",")
+	for rest != "" {
+		// The default value may contain commas, so it consumes the rest of the tag.
+		if value, ok := strings.CutPrefix(rest, "default="); ok {
+			opts.defaultValue, opts.hasDefault = value, true
+			break
+		}
+
+		var opt string
+		opt, rest, _ = strings.Cut(rest, ",")
+		switch opt {
+		case "omitempty":
+			opts.omitEmpty = true
+		case "inline":
+			opts.inline = true
+		case "extra":
+			opts.extra = true
+		}
+	}
+
+	return name, opts
+}
+
+// fieldCache caches the csv tagged fields of each struct type.
+var fieldCache sync.Map // map[reflect.Type]*typeFields
+
+// extraType is the type of fields tagged "extra".
+var extraType = reflect.TypeOf(map[string]string(nil))
+
+// cachedFields returns the csv tagged fields of a struct type.
+func cachedFields(t reflect.Type) (*typeFields, error) {
+	if tf, ok := fieldCache.Load(t); ok {
+		return tf.(*typeFields), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported type: %s", t)
+	}
+
+	var fields []field
+	collectFields(t, nil, &fields)
+
+	// If several fields map onto the same column, the shallowest wins (like
+	// Go's own field promotion).
+	tf := &typeFields{}
+
+	var deduped []field
+	seen := make(map[string]int)
+	for _, f := range fields {
+		if f.extra {
+			if tf.extra != nil {
+				return nil, fmt.Errorf("%s has more than one extra field", t)
+			}
+			if t.FieldByIndex(f.index).Type != extraType {
+				return nil, fmt.Errorf("extra field %s must be a map[string]string", f.name)
+			}
+			tf.extra = &f
+			continue
+		}
+
+		if i, ok := seen[f.column]; ok {
+			if len(f.index) < len(deduped[i].index) {
+				deduped[i] = f
+			}
+			continue
+		}
+
+		seen[f.column] = len(deduped)
+		deduped = append(deduped, f)
+	}
+
+	tf.fields = deduped
+
+	fieldCache.Store(t, tf)
+
+	return tf, nil
+}
+
+func collectFields(t reflect.Type, parent []int, fields *[]field) {
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag := structField.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+
+		index := append(append([]int{}, parent...), i)
+
+		fieldType := structField.Type
+		pointer := fieldType.Kind() == reflect.Pointer
+		if pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if opts.extra {
+			*fields = append(*fields, field{
+				index: index,
+				name:  structField.Name,
+				extra: true,
+			})
+			continue
+		}
+
+		textUnmarshaler := reflect.PointerTo(fieldType).Implements(textUnmarshalerType)
+
+		// Flatten embedded structs (and inline struct fields).
+		if fieldType.Kind() == reflect.Struct && !textUnmarshaler &&
+			((structField.Anonymous && name == "") || opts.inline) {
+			collectFields(fieldType, index, fields)
+			continue
+		}
+
+		if name == "" {
+			continue // Skip fields without a CSV tag
+		}
+
+		*fields = append(*fields, field{
+			index:           index,
+			name:            structField.Name,
+			header:          name,
+			column:          strings.ToLower(name),
+			omitEmpty:       opts.omitEmpty,
+			defaultValue:    opts.defaultValue,
+			hasDefault:      opts.hasDefault,
+			pointer:         pointer,
+			textUnmarshaler: textUnmarshaler,
+		})
+	}
+}
+
+// fieldForWrite returns the field of v with the given index sequence, allocating
+// any nil embedded struct pointers along the way.
+func fieldForWrite(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// fieldForRead returns the field of v with the given index sequence. It returns
+// an invalid value if an embedded struct pointer along the way is nil.
+func fieldForRead(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
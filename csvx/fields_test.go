@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Designated struct {
+	Designator string `csv:"Designator"`
+}
+
+type Position struct {
+	X float64 `csv:"X"`
+	Y float64 `csv:"Y"`
+}
+
+type Row struct {
+	Designated
+	Position `csv:",inline"`
+	Value    string   `csv:"Value"`
+	Qty      int      `csv:"Qty,default=1"`
+	Offset   *float64 `csv:"Offset"`
+	Notes    string   `csv:"Notes,omitempty"`
+	Count    int      `csv:"Count,omitempty"`
+}
+
+type PointerRow struct {
+	*Designated
+	Value string `csv:"Value"`
+}
+
+type ShadowedRow struct {
+	Designated
+	Designator string `csv:"Designator"`
+}
+
+func TestUnmarshalFieldTypes(t *testing.T) {
+	rows, err := csvx.Unmarshal[Row](strings.NewReader(`Designator,X,Y,Value,Qty,Offset
+R1,1.5,2.5,10k,,0
+R2,3,4,4k7,2,
+`))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "R1", rows[0].Designator)
+	assert.Equal(t, 1.5, rows[0].X)
+	assert.Equal(t, 2.5, rows[0].Y)
+	assert.Equal(t, 1, rows[0].Qty)
+	require.NotNil(t, rows[0].Offset)
+	assert.Equal(t, 0.0, *rows[0].Offset)
+
+	assert.Equal(t, "R2", rows[1].Designator)
+	assert.Equal(t, 2, rows[1].Qty)
+	assert.Nil(t, rows[1].Offset)
+}
+
+func TestUnmarshalDefaultMissingColumn(t *testing.T) {
+	rows, err := csvx.Unmarshal[Row](strings.NewReader(`Designator,Value
+R1,10k
+`))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	assert.Equal(t, 1, rows[0].Qty)
+}
+
+func TestUnmarshalEmbeddedPointer(t *testing.T) {
+	rows, err := csvx.Unmarshal[PointerRow](strings.NewReader(`Designator,Value
+R1,10k
+`))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	require.NotNil(t, rows[0].Designated)
+	assert.Equal(t, "R1", rows[0].Designator)
+
+	var sb strings.Builder
+	require.NoError(t, csvx.Marshal(&sb, []PointerRow{{Value: "4k7"}}))
+	assert.Equal(t, "Designator,Value\n,4k7\n", sb.String())
+}
+
+func TestUnmarshalShadowedField(t *testing.T) {
+	rows, err := csvx.Unmarshal[ShadowedRow](strings.NewReader(`Designator
+R1
+`))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	// The outer field wins.
+	assert.Equal(t, "R1", rows[0].Designator)
+	assert.Equal(t, "", rows[0].Designated.Designator)
+}
+
+func TestMarshalFieldTypes(t *testing.T) {
+	offset := 0.0
+
+	var sb strings.Builder
+	require.NoError(t, csvx.Marshal(&sb, []Row{
+		{Designated: Designated{"R1"}, Position: Position{1.5, 2.5}, Value: "10k", Qty: 1, Offset: &offset},
+		{Designated: Designated{"R2"}, Value: "4k7", Qty: 2, Notes: "fragile", Count: 3},
+	}))
+
+	assert.Equal(t, `Designator,X,Y,Value,Qty,Offset,Notes,Count
+R1,1.5,2.5,10k,1,0,,
+R2,0,0,4k7,2,,fragile,3
+`, sb.String())
+}
+
+type ExtraRow struct {
+	Designator string            `csv:"Designator"`
+	Value      string            `csv:"Value"`
+	Fields     map[string]string `csv:",extra"`
+}
+
+func TestUnmarshalExtra(t *testing.T) {
+	rows, err := csvx.Unmarshal[ExtraRow](strings.NewReader(`Designator,Value,MPN,Manufacturer
+R1,10k,RC0603FR-0710KL,Yageo
+R2,4k7,,
+`))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, map[string]string{"MPN": "RC0603FR-0710KL", "Manufacturer": "Yageo"}, rows[0].Fields)
+	assert.Nil(t, rows[1].Fields)
+}
+
+func TestMarshalExtra(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, csvx.Marshal(&sb, []ExtraRow{
+		{Designator: "R1", Value: "10k", Fields: map[string]string{"MPN": "RC0603FR-0710KL", "value": "ignored"}},
+		{Designator: "R2", Value: "4k7", Fields: map[string]string{"Manufacturer": "Yageo"}},
+	}))
+
+	assert.Equal(t, `Designator,Value,MPN,Manufacturer
+R1,10k,RC0603FR-0710KL,
+R2,4k7,,Yageo
+`, sb.String())
+}
+
+func TestExtraWrongType(t *testing.T) {
+	type row struct {
+		Designator string `csv:"Designator"`
+		Fields     string `csv:",extra"`
+	}
+
+	_, err := csvx.Unmarshal[row](strings.NewReader("Designator\nR1\n"))
+	assert.Error(t, err)
+}
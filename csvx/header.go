@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"strings"
+)
+
+// HasColumns reports whether a header has all of the named columns (ignoring
+// case and surrounding whitespace).
+func HasColumns(header []string, names ...string) bool {
+	for _, name := range names {
+		found := false
+		for _, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasHeader reports whether the first line of a file (after any lines starting
+// with comment, if it isn't zero) is a CSV header with all of the named columns
+// (see HasColumns).
+func HasHeader(head []byte, comment rune, names ...string) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(head))
+
+	var line string
+	for scanner.Scan() {
+		line = scanner.Text()
+		if !isComment(line, comment) {
+			break
+		}
+	}
+
+	header, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return false
+	}
+
+	return HasColumns(header, names...)
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasColumns(t *testing.T) {
+	header := []string{"Ref", " Pos X ", "pos y"}
+
+	assert.True(t, csvx.HasColumns(header, "ref", "Pos X", "Pos Y"))
+	assert.True(t, csvx.HasColumns(header))
+	assert.False(t, csvx.HasColumns(header, "Ref", "Rotation"))
+}
+
+func TestHasHeader(t *testing.T) {
+	head := []byte("# Generated file\n# Units: mm\nDesignator,Position X,Position Y\nR1,1,2\n")
+
+	assert.True(t, csvx.HasHeader(head, '#', "designator", "position x"))
+	assert.False(t, csvx.HasHeader(head, 0, "Designator"))
+	assert.False(t, csvx.HasHeader(head, '#', "Designator", "Rotation"))
+
+	assert.True(t, csvx.HasHeader([]byte("QTY,Refdes\r\n1,R1\r\n"), 0, "qty", "refdes"))
+	assert.False(t, csvx.HasHeader([]byte(`"unterminated`), 0, "unterminated"))
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Marshal writes a slice of structs to an io.Writer as CSV. The header row is
+// taken from the csv struct tags, fields without a tag (or tagged "-") are skipped.
+// Nil pointers, and zero values of fields tagged omitempty, are written as empty
+// cells. The keys of the extra field (if any) are written as additional columns,
+// in sorted order.
+func Marshal[T any](w io.Writer, items []T) error {
+	writer := csv.NewWriter(w)
+
+	tf, err := cachedFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	headers := make([]string, len(tf.fields))
+	for i, f := range tf.fields {
+		headers[i] = f.header
+	}
+
+	extraColumns := extraColumns(items, tf)
+	headers = append(headers, extraColumns...)
+
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	record := make([]string, len(headers))
+	for _, item := range items {
+		itemVal := reflect.ValueOf(item)
+
+		for i, f := range tf.fields {
+			record[i], err = marshalValue(fieldForRead(itemVal, f.index), f)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(extraColumns) > 0 {
+			extra := extraValues(itemVal, tf)
+			for i, column := range extraColumns {
+				record[len(tf.fields)+i] = extra[column]
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// extraColumns returns the (sorted) union of the extra field's keys, skipping
+// any that clash with a tagged field.
+func extraColumns[T any](items []T, tf *typeFields) []string {
+	if tf.extra == nil {
+		return nil
+	}
+
+	bound := make(map[string]bool, len(tf.fields))
+	for _, f := range tf.fields {
+		bound[f.column] = true
+	}
+
+	seen := make(map[string]bool)
+	var columns []string
+	for _, item := range items {
+		for column := range extraValues(reflect.ValueOf(item), tf) {
+			if seen[column] || bound[strings.ToLower(column)] {
+				continue
+			}
+
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+// extraValues returns the extra field of an item (if any).
+func extraValues(itemVal reflect.Value, tf *typeFields) map[string]string {
+	if tf.extra == nil {
+		return nil
+	}
+
+	field := fieldForRead(itemVal, tf.extra.index)
+	if !field.IsValid() {
+		return nil
+	}
+
+	return field.Interface().(map[string]string)
+}
+
+// marshalValue formats a field as a cell value.
+func marshalValue(field reflect.Value, f field) (string, error) {
+	if !field.IsValid() {
+		return "", nil
+	}
+
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+
+	if f.omitEmpty && field.IsZero() {
+		return "", nil
+	}
+
+	// If field implements encoding.TextMarshaler, use it
+	if field.Type().Implements(textMarshalerType) {
+		text, err := field.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal field %s: %w", f.name, err)
+		}
+		return string(text), nil
+	}
+
+	// Convert from primitive types
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type: %s", f.name)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	var sb strings.Builder
+	err := csvx.Marshal(&sb, []Person{
+		{Name: "John Doe", Age: 30, Birthdate: time.Date(1994, 6, 15, 0, 0, 0, 0, time.UTC), Active: true},
+		{Name: "Smith, Jane", Age: 25, Birthdate: time.Date(1998, 9, 10, 0, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `name,age,birthdate,active
+John Doe,30,1994-06-15T00:00:00Z,true
+"Smith, Jane",25,1998-09-10T00:00:00Z,false
+`, sb.String())
+
+	// Round trip
+	people, err := csvx.Unmarshal[Person](strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	require.Len(t, people, 2)
+
+	assert.Equal(t, "Smith, Jane", people[1].Name)
+}
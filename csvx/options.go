@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"golang.org/x/text/encoding"
+)
+
+// Whitespace is a Delimiter for whitespace aligned text tables (eg. KiCad's
+// ASCII .pos files). Fields are separated by runs of spaces or tabs, and may be
+// double quoted.
+const Whitespace = ' '
+
+// options configures a Decoder.
+type options struct {
+	Dialect
+	// comment is the comment character (0 if comments aren't supported).
+	comment rune
+	// skipLines is the number of lines to skip before the header.
+	skipLines int
+	// isHeader finds the header row (if set).
+	isHeader func(record []string) bool
+}
+
+// Option configures a Decoder. Any part of the Dialect that isn't set explicitly
+// is detected from the start of the file.
+type Option func(*options)
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithEncoding sets the character encoding (eg. unicode.UTF16(...)). A leading
+// byte order mark is always removed.
+func WithEncoding(enc encoding.Encoding) Option {
+	return func(o *options) {
+		o.Encoding = enc
+	}
+}
+
+// WithDelimiter sets the field delimiter (or Whitespace).
+func WithDelimiter(delimiter rune) Option {
+	return func(o *options) {
+		o.Delimiter = delimiter
+	}
+}
+
+// WithDecimalSeparator sets the decimal separator used by floating point fields.
+func WithDecimalSeparator(separator rune) Option {
+	return func(o *options) {
+		o.DecimalSeparator = separator
+	}
+}
+
+// WithComment sets the comment character (by default there is none, so values
+// may start with any character). Lines starting with it are skipped.
+func WithComment(comment rune) Option {
+	return func(o *options) {
+		o.comment = comment
+	}
+}
+
+// WithSkipLines skips a fixed number of lines (eg. a title) before the header.
+func WithSkipLines(n int) Option {
+	return func(o *options) {
+		o.skipLines = n
+	}
+}
+
+// WithHeader skips any rows before the first one that isHeader returns true
+// for (eg. a preamble). Commented out rows are also considered, with the comment
+// characters removed, to support headers such as "# Ref Val Package".
+func WithHeader(isHeader func(record []string) bool) Option {
+	return func(o *options) {
+		o.isHeader = isHeader
+	}
+}
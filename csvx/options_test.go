@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Component struct {
+	Ref  string  `csv:"Ref"`
+	Val  string  `csv:"Val"`
+	PosX float64 `csv:"PosX"`
+	Side string  `csv:"Side"`
+}
+
+func TestWithComment(t *testing.T) {
+	data := "Ref,Val,PosX,Side\nJ1,#RESET,1.5,top\n#2,10k,2,bottom\n"
+
+	// By default, there are no comments so values may start with '#'.
+	rows, err := csvx.Unmarshal[Component](strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "#RESET", rows[0].Val)
+	assert.Equal(t, "#2", rows[1].Ref)
+
+	rows, err = csvx.Unmarshal[Component](strings.NewReader(data), csvx.WithComment('#'))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	assert.Equal(t, "#RESET", rows[0].Val)
+}
+
+func TestWithSkipLines(t *testing.T) {
+	rows, err := csvx.Unmarshal[Component](strings.NewReader(`Exported by "Some Tool"
+Units: mm, "Board: rev A
+Ref,Val,PosX,Side
+R1,10k,1.5,top
+`), csvx.WithSkipLines(2))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Component{{Ref: "R1", Val: "10k", PosX: 1.5, Side: "top"}}, rows)
+}
+
+func TestWithHeader(t *testing.T) {
+	dec := csvx.NewDecoder[Component](strings.NewReader(`Pick and Place Report
+Date: 2026-01-02
+Units used: mm
+
+Ref,Val,PosX,Side
+R1,10k,1.5,top
+R2,10k,abc,top
+`), csvx.WithHeader(func(record []string) bool {
+		return len(record) > 0 && record[0] == "Ref"
+	}))
+
+	header, err := dec.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Ref", "Val", "PosX", "Side"}, header)
+
+	var row Component
+	require.NoError(t, dec.Decode(&row))
+	assert.Equal(t, Component{Ref: "R1", Val: "10k", PosX: 1.5, Side: "top"}, row)
+	assert.Equal(t, 6, dec.Line())
+
+	err = dec.Decode(&row)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 7")
+}
+
+func TestWithHeaderMissing(t *testing.T) {
+	_, err := csvx.Unmarshal[Component](strings.NewReader("R1,10k,1.5,top\n"),
+		csvx.WithHeader(func(record []string) bool { return false }))
+	assert.Error(t, err)
+}
+
+func TestWhitespace(t *testing.T) {
+	dec := csvx.NewDecoder[Component](strings.NewReader(`### Module positions - created on 2026-01-02 ###
+### Printed by KiCad version 9.0.0
+## Unit = mm, Angle = deg.
+## Side : All
+# Ref     Val       Package              PosX       PosY       Rot  Side
+C1        100n      C_0603_1608Metric   28.1940  -173.2600    0.0000  top
+J1        "USB C"   USB_C_Receptacle    20.0660  -177.0700   90.0000  bottom
+## End
+`), csvx.WithDelimiter(csvx.Whitespace), csvx.WithComment('#'), csvx.WithHeader(func(record []string) bool {
+		return len(record) > 0 && record[0] == "Ref"
+	}))
+
+	var rows []Component
+	for row, err := range dec.All() {
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+
+	assert.Equal(t, []Component{
+		{Ref: "C1", Val: "100n", PosX: 28.194, Side: "top"},
+		{Ref: "J1", Val: "USB C", PosX: 20.066, Side: "bottom"},
+	}, rows)
+	assert.Equal(t, 7, dec.Line())
+}
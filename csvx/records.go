@@ -0,0 +1,141 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package csvx
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// recordReader reads records one at a time.
+type recordReader interface {
+	Read() ([]string, error)
+	// Line returns the line number of the most recently read record.
+	Line() int
+}
+
+// csvRecords reads delimited records.
+type csvRecords struct {
+	reader *csv.Reader
+	// offset is the number of lines read before the reader was created.
+	offset int
+}
+
+func newCSVRecords(r io.Reader, dialect Dialect, comment rune, offset int) *csvRecords {
+	reader := csv.NewReader(r)
+	reader.Comma = dialect.Delimiter
+	reader.Comment = comment
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	return &csvRecords{reader: reader, offset: offset}
+}
+
+func (r *csvRecords) Read() ([]string, error) {
+	return r.reader.Read()
+}
+
+func (r *csvRecords) Line() int {
+	line, _ := r.reader.FieldPos(0)
+	return r.offset + line
+}
+
+// whitespaceRecords reads whitespace aligned records (see Whitespace).
+type whitespaceRecords struct {
+	reader  *bufio.Reader
+	comment rune
+	// line is the number of lines read, and recordLine is the line of the most
+	// recently read record.
+	line       int
+	recordLine int
+}
+
+func (r *whitespaceRecords) Read() ([]string, error) {
+	for {
+		line, err := readLine(r.reader)
+		if err != nil {
+			return nil, err
+		}
+		r.line++
+
+		if strings.TrimSpace(line) == "" || isComment(line, r.comment) {
+			continue
+		}
+
+		record, err := SplitFields(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		r.recordLine = r.line
+
+		return record, nil
+	}
+}
+
+func (r *whitespaceRecords) Line() int {
+	return r.recordLine
+}
+
+// readLine reads a single line (without the line ending). It returns io.EOF if
+// there are no more lines.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// isComment reports whether a line is a comment.
+func isComment(line string, comment rune) bool {
+	return comment != 0 && strings.HasPrefix(strings.TrimLeftFunc(line, unicode.IsSpace), string(comment))
+}
+
+// SplitFields splits a line on runs of whitespace (as in the Whitespace format).
+// Fields may be double quoted (to include whitespace).
+func SplitFields(line string) ([]string, error) {
+	var fields []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		if line[0] == '"' {
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted field")
+			}
+			fields = append(fields, line[1:end+1])
+			line = line[end+2:]
+			continue
+		}
+
+		end := strings.IndexFunc(line, unicode.IsSpace)
+		if end < 0 {
+			end = len(line)
+		}
+		fields = append(fields, line[:end])
+		line = line[end:]
+	}
+
+	return fields, nil
+}
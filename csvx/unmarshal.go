@@ -18,118 +18,102 @@
 package csvx
 
 import (
-	"encoding"
-	"encoding/csv"
 	"fmt"
 	"io"
 	"reflect"
-	"strconv"
 	"strings"
 )
 
 // Unmarshal reads a CSV file from an io.Reader and unmarshals it into a slice of structs.
-func Unmarshal[T any](r io.Reader) ([]T, error) {
-	reader := csv.NewReader(r)
-	reader.TrimLeadingSpace = true
-	reader.Comment = '#'
+// The dialect is detected (see Decoder) unless it's set with options.
+func Unmarshal[T any](r io.Reader, opts ...Option) ([]T, error) {
+	dec := NewDecoder[T](r, opts...)
 
-	// Read headers
-	headers, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	var results []T
+	for item, err := range dec.All() {
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, item)
 	}
 
-	// Normalize headers to lowercase for case-insensitive matching
-	headerMap := make(map[string]int)
-	for i, h := range headers {
-		headerMap[strings.ToLower(h)] = i
+	return results, nil
+}
+
+// UnmarshalRecords unmarshals already split records (eg. spreadsheet rows) into
+// a slice of structs, using the same rules as Unmarshal. The first record is the
+// header, short records are padded with empty fields and empty records are
+// skipped.
+func UnmarshalRecords[T any](records [][]string) ([]T, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header")
 	}
 
-	var results []T
+	columns, err := mapColumns[T](records[0])
+	if err != nil {
+		return nil, err
+	}
 
-	// Process rows
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to read CSV row: %w", err)
+	var results []T
+	for i, record := range records[1:] {
+		if isEmptyRecord(record) {
+			continue
 		}
 
 		var item T
-		itemVal := reflect.ValueOf(&item).Elem()
-		itemType := itemVal.Type()
-
-		// Map CSV fields to struct fields
-		for i := 0; i < itemVal.NumField(); i++ {
-			field := itemVal.Field(i)
-			fieldType := itemType.Field(i)
-
-			// Get column name from struct tag
-			tag := fieldType.Tag.Get("csv")
-			if tag == "" {
-				continue // Skip fields without a CSV tag
-			}
+		if err := columns.decodeRecord(reflect.ValueOf(&item).Elem(), record); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
 
-			// Lookup CSV column index
-			colIdx, exists := headerMap[strings.ToLower(tag)]
-			if !exists {
-				continue // Skip if column is not found
-			}
+		results = append(results, item)
+	}
 
-			rawValue := record[colIdx]
+	return results, nil
+}
 
-			// If field implements encoding.TextUnmarshaler, use it
-			if field.CanAddr() {
-				if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
-					if err := unmarshaler.UnmarshalText([]byte(rawValue)); err != nil {
-						return nil, fmt.Errorf("failed to unmarshal field %s: %w", fieldType.Name, err)
-					}
-					continue
-				}
-			}
+// FindHeader finds the header row of T in the first few records (eg. of a
+// spreadsheet with a title above the table). The header is the record that
+// matches the most csv tags of T (at least two). It returns -1 if no header
+// was found.
+func FindHeader[T any](records [][]string, maxRows int) int {
+	tf, err := cachedFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return -1
+	}
 
-			// Is the field empty?
-			if rawValue == "" {
-				continue
-			}
+	columns := make(map[string]bool, len(tf.fields))
+	for _, f := range tf.fields {
+		columns[f.column] = true
+	}
 
-			// Convert to primitive types
-			switch field.Kind() {
-			case reflect.String:
-				field.SetString(rawValue)
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if intValue, err := strconv.ParseInt(rawValue, 10, 64); err == nil {
-					field.SetInt(intValue)
-				} else {
-					return nil, fmt.Errorf("invalid int for field %s: %s", fieldType.Name, rawValue)
-				}
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				if uintValue, err := strconv.ParseUint(rawValue, 10, 64); err == nil {
-					field.SetUint(uintValue)
-				} else {
-					return nil, fmt.Errorf("invalid uint for field %s: %s", fieldType.Name, rawValue)
-				}
-			case reflect.Float32, reflect.Float64:
-				if floatValue, err := strconv.ParseFloat(rawValue, 64); err == nil {
-					field.SetFloat(floatValue)
-				} else {
-					return nil, fmt.Errorf("invalid float for field %s: %s", fieldType.Name, rawValue)
-				}
-			case reflect.Bool:
-				if boolValue, err := strconv.ParseBool(strings.ToLower(rawValue)); err == nil {
-					field.SetBool(boolValue)
-				} else {
-					return nil, fmt.Errorf("invalid bool for field %s: %s", fieldType.Name, rawValue)
-				}
-			default:
-				return nil, fmt.Errorf("unsupported field type: %s", fieldType.Name)
+	best, bestMatches := -1, 1
+	for i, record := range records {
+		if i >= maxRows {
+			break
+		}
+
+		var matches int
+		for _, value := range record {
+			if columns[strings.ToLower(strings.TrimSpace(value))] {
+				matches++
 			}
 		}
 
-		results = append(results, item)
+		if matches > bestMatches {
+			best, bestMatches = i, matches
+		}
 	}
 
-	return results, nil
+	return best
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
 }
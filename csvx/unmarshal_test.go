@@ -58,3 +58,35 @@ Jane Smith,25,"1998-09-10T00:00:00Z",false
 	assert.Equal(t, time.Date(1998, 9, 10, 0, 0, 0, 0, time.UTC), people[1].Birthdate)
 	assert.Equal(t, false, people[1].Active)
 }
+
+func TestUnmarshalRecords(t *testing.T) {
+	records := [][]string{
+		{"People"},
+		{},
+		{"Name", "Age", "Active"},
+		{"John Doe", "30", "true"},
+		{"", "", ""},
+		{"Jane Smith", "25"},
+	}
+
+	header := csvx.FindHeader[Person](records, 10)
+	require.Equal(t, 2, header)
+
+	people, err := csvx.UnmarshalRecords[Person](records[header:])
+	require.NoError(t, err)
+	require.Len(t, people, 2)
+
+	assert.Equal(t, "John Doe", people[0].Name)
+	assert.Equal(t, 30, people[0].Age)
+	assert.True(t, people[0].Active)
+
+	// Short records are padded.
+	assert.Equal(t, "Jane Smith", people[1].Name)
+	assert.False(t, people[1].Active)
+
+	// No header within the first rows.
+	assert.Equal(t, -1, csvx.FindHeader[Person](records, 2))
+
+	_, err = csvx.UnmarshalRecords[Person](nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package eagle
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+)
+
+func init() {
+	bom.RegisterReader(&bomReader{})
+}
+
+// ReadBOM reads a BOM from an Eagle schematic (.sch) or board (.brd). Only parts
+// with an LCSC part number attribute are included, parts with the same part
+// number, value and package are grouped into a single entry.
+func ReadBOM(r io.Reader) ([]bom.Entry, error) {
+	doc, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []bomPart
+	switch {
+	case doc.Drawing.Schematic != nil:
+		parts = schematicParts(doc.Drawing.Schematic)
+	case doc.Drawing.Board != nil:
+		parts = boardParts(doc.Drawing.Board)
+	default:
+		return nil, fmt.Errorf("not an Eagle schematic or board")
+	}
+
+	var entries []bom.Entry
+	index := make(map[bomPart]int)
+	for _, p := range parts {
+		key := p
+		key.designator = ""
+
+		if i, ok := index[key]; ok {
+			entries[i].Reference = entries[i].Reference.Merge(bom.Designators{p.designator})
+			entries[i].Qty = len(entries[i].Reference)
+			continue
+		}
+
+		index[key] = len(entries)
+		entries = append(entries, bom.Entry{
+			Reference: bom.Designators{p.designator},
+			Value:     p.value,
+			Footprint: p.footprint,
+			Qty:       1,
+			LCSC:      p.lcsc,
+		})
+	}
+
+	return entries, nil
+}
+
+// bomPart is a single part with an LCSC part number.
+type bomPart struct {
+	designator string
+	value      string
+	footprint  string
+	lcsc       string
+}
+
+// schematicParts returns the parts in a schematic that have an LCSC part number,
+// either on the part itself or on its library device.
+func schematicParts(sch *schematic) []bomPart {
+	type deviceKey struct {
+		library, urn, deviceSet, device string
+	}
+
+	devices := make(map[deviceKey]device)
+	for _, lib := range sch.Libraries {
+		for _, ds := range lib.DeviceSets {
+			for _, dev := range ds.Devices {
+				devices[deviceKey{lib.Name, lib.URN, ds.Name, dev.Name}] = dev
+			}
+		}
+	}
+
+	var parts []bomPart
+	for _, p := range sch.Parts {
+		dev, ok := devices[deviceKey{p.Library, p.LibraryURN, p.DeviceSet, p.Device}]
+		if !ok || dev.Package == "" {
+			// Supply symbols, frames etc.
+			continue
+		}
+
+		lcsc := lcscPartNumber(p.Attributes)
+		if lcsc == "" {
+			for _, tech := range dev.Technologies {
+				if tech.Name == p.Technology {
+					lcsc = lcscPartNumber(tech.Attributes)
+				}
+			}
+		}
+		if lcsc == "" {
+			continue
+		}
+
+		value := p.Value
+		if value == "" {
+			value = p.DeviceSet + p.Technology + p.Device
+		}
+
+		parts = append(parts, bomPart{
+			designator: p.Name,
+			value:      value,
+			footprint:  dev.Package,
+			lcsc:       lcsc,
+		})
+	}
+
+	return parts
+}
+
+// boardParts returns the elements on a board that have an LCSC part number.
+func boardParts(brd *board) []bomPart {
+	var parts []bomPart
+	for _, elem := range brd.Elements {
+		lcsc := lcscPartNumber(elem.Attributes)
+		if elem.Package == "" || lcsc == "" {
+			continue
+		}
+
+		parts = append(parts, bomPart{
+			designator: elem.Name,
+			value:      elem.Value,
+			footprint:  elem.Package,
+			lcsc:       lcsc,
+		})
+	}
+
+	return parts
+}
+
+// bomReader reads BOMs from Eagle schematics and boards.
+type bomReader struct{}
+
+func (r *bomReader) Name() string {
+	return "eagle"
+}
+
+func (r *bomReader) Sniff(name string, head []byte) bool {
+	return sniff(name, head, ".sch") || sniff(name, head, ".brd")
+}
+
+func (r *bomReader) Read(rd io.Reader) ([]bom.Entry, error) {
+	return ReadBOM(rd)
+}
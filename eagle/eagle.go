@@ -0,0 +1,160 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package eagle reads Autodesk Eagle (and Fusion 360 Electronics) board and
+// schematic XML files.
+package eagle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lcscAttributeNames are the (upper case) part attribute names that hold an
+// LCSC part number.
+var lcscAttributeNames = []string{"LCSC", "LCSC#", "LCSC_PN", "LCSC_PART", "LCSC PN", "LCSC PART", "LCSC PART NUMBER"}
+
+type document struct {
+	XMLName xml.Name `xml:"eagle"`
+	Drawing struct {
+		Board     *board     `xml:"board"`
+		Schematic *schematic `xml:"schematic"`
+	} `xml:"drawing"`
+}
+
+type board struct {
+	Elements []element `xml:"elements>element"`
+}
+
+type element struct {
+	Name       string      `xml:"name,attr"`
+	Library    string      `xml:"library,attr"`
+	Package    string      `xml:"package,attr"`
+	Value      string      `xml:"value,attr"`
+	X          float64     `xml:"x,attr"`
+	Y          float64     `xml:"y,attr"`
+	Rot        string      `xml:"rot,attr"`
+	Attributes []attribute `xml:"attribute"`
+}
+
+type schematic struct {
+	Libraries []library `xml:"libraries>library"`
+	Parts     []part    `xml:"parts>part"`
+}
+
+type library struct {
+	Name       string      `xml:"name,attr"`
+	URN        string      `xml:"urn,attr"`
+	DeviceSets []deviceSet `xml:"devicesets>deviceset"`
+}
+
+type deviceSet struct {
+	Name    string   `xml:"name,attr"`
+	Devices []device `xml:"devices>device"`
+}
+
+type device struct {
+	Name         string       `xml:"name,attr"`
+	Package      string       `xml:"package,attr"`
+	Technologies []technology `xml:"technologies>technology"`
+}
+
+type technology struct {
+	Name       string      `xml:"name,attr"`
+	Attributes []attribute `xml:"attribute"`
+}
+
+type part struct {
+	Name       string      `xml:"name,attr"`
+	Library    string      `xml:"library,attr"`
+	LibraryURN string      `xml:"library_urn,attr"`
+	DeviceSet  string      `xml:"deviceset,attr"`
+	Device     string      `xml:"device,attr"`
+	Technology string      `xml:"technology,attr"`
+	Value      string      `xml:"value,attr"`
+	Attributes []attribute `xml:"attribute"`
+}
+
+type attribute struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// decode decodes an Eagle XML document.
+func decode(r io.Reader) (*document, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("could not parse XML: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// sniff reports whether a file looks like an Eagle XML document with the given
+// extension (eg. ".brd"). The drawing itself comes after the (lengthy) layer
+// definitions, so the extension is used to tell boards and schematics apart.
+func sniff(name string, head []byte, ext string) bool {
+	return strings.EqualFold(filepath.Ext(name), ext) && bytes.Contains(head, []byte("<eagle"))
+}
+
+// parseRotation parses an Eagle rotation (eg. "R90", "MR180" or "SMR45"). The
+// "M" flag indicates the part is mirrored onto the bottom side and the "S" (spin)
+// flag is ignored.
+func parseRotation(rot string) (float64, bool, error) {
+	if rot == "" {
+		return 0, false, nil
+	}
+
+	var mirrored bool
+	flags := rot
+	for len(flags) > 0 && (flags[0] == 'S' || flags[0] == 'M') {
+		if flags[0] == 'M' {
+			mirrored = true
+		}
+		flags = flags[1:]
+	}
+
+	angle, ok := strings.CutPrefix(flags, "R")
+	if !ok {
+		return 0, false, fmt.Errorf("invalid rotation: %q", rot)
+	}
+
+	degrees, err := strconv.ParseFloat(angle, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid rotation: %q", rot)
+	}
+
+	return degrees, mirrored, nil
+}
+
+// lcscPartNumber returns the LCSC part number from a set of attributes (if any).
+func lcscPartNumber(attrs []attribute) string {
+	for _, attr := range attrs {
+		for _, name := range lcscAttributeNames {
+			if strings.ToUpper(attr.Name) == name && attr.Value != "" {
+				return strings.TrimSpace(attr.Value)
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package eagle
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+)
+
+func init() {
+	placement.RegisterReader(&placementReader{})
+}
+
+// ReadPlacements reads the component placements from an Eagle board (.brd).
+// Mirrored elements are placed on the bottom side, with their rotation converted
+// into KiCad's convention (see bottomRotation). Elements without a package (eg.
+// frames and logos) are skipped.
+func ReadPlacements(r io.Reader) ([]placement.Placement, error) {
+	doc, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Drawing.Board == nil {
+		return nil, fmt.Errorf("not an Eagle board")
+	}
+
+	var placements []placement.Placement
+	for _, elem := range doc.Drawing.Board.Elements {
+		if elem.Package == "" {
+			continue
+		}
+
+		rot, mirrored, err := parseRotation(elem.Rot)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse element %s: %w", elem.Name, err)
+		}
+
+		side := "top"
+		if mirrored {
+			side = "bottom"
+			rot = bottomRotation(rot)
+		}
+
+		placements = append(placements, placement.Placement{
+			Ref:     elem.Name,
+			Val:     elem.Value,
+			Package: elem.Package,
+			PosX:    elem.X,
+			PosY:    elem.Y,
+			Rot:     rot,
+			Side:    side,
+		})
+	}
+
+	return placements, nil
+}
+
+// bottomRotation converts the rotation of a mirrored Eagle element (eg. 90 for
+// "MR90") into KiCad's convention for bottom side footprints.
+//
+// Eagle mirrors an element left to right after rotating it, so its rotation
+// appears clockwise when viewed from the top. KiCad instead mirrors footprints
+// top to bottom and then rotates them counter-clockwise. An element mirrored in
+// Eagle with a rotation of θ is equivalent to a KiCad footprint on the bottom
+// side with a rotation of 180-θ.
+func bottomRotation(rot float64) float64 {
+	rot = math.Mod(180-rot, 360)
+	if rot < 0 {
+		rot += 360
+	}
+	return rot
+}
+
+// placementReader reads component placements from Eagle boards.
+type placementReader struct{}
+
+func (r *placementReader) Name() string {
+	return "eagle"
+}
+
+func (r *placementReader) Sniff(name string, head []byte) bool {
+	return sniff(name, head, ".brd")
+}
+
+func (r *placementReader) Read(rd io.Reader) ([]placement.Placement, error) {
+	return ReadPlacements(rd)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package eagle_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/eagle"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPlacements(t *testing.T) {
+	f, err := os.Open("testdata/board.brd")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	placements, err := eagle.ReadPlacements(f)
+	require.NoError(t, err)
+
+	expected := []placement.Placement{
+		{Ref: "R1", Val: "10k", Package: "R0603", PosX: 10.16, PosY: 20.32, Rot: 90, Side: "top"},
+		{Ref: "R2", Val: "10k", Package: "R0603", PosX: 12.7, PosY: 20.32, Rot: 0, Side: "bottom"},
+		{Ref: "C1", Val: "100n", Package: "C0603", PosX: 5.08, PosY: 7.62, Rot: 0, Side: "top"},
+		{Ref: "U1", Val: "AP2112K-3.3", Package: "SOT23-5", PosX: 25.4, PosY: 15.24, Rot: 270, Side: "bottom"},
+	}
+
+	assert.Equal(t, expected, placements)
+}
+
+func TestLoadPlacements(t *testing.T) {
+	placements, format, err := placement.Load("testdata/board.brd", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "eagle", format)
+	assert.Len(t, placements, 4)
+}
+
+func TestReadPlacementsMirrored(t *testing.T) {
+	placements, err := eagle.ReadPlacements(strings.NewReader(`<eagle><drawing><board><elements>
+<element name="R1" package="R0603" x="0" y="0" rot="MR0"/>
+<element name="R2" package="R0603" x="0" y="0" rot="MR90"/>
+<element name="R3" package="R0603" x="0" y="0" rot="MR270"/>
+<element name="R4" package="R0603" x="0" y="0" rot="SMR45"/>
+</elements></board></drawing></eagle>`))
+	require.NoError(t, err)
+	require.Len(t, placements, 4)
+
+	for i, rot := range []float64{180, 90, 270, 135} {
+		assert.Equal(t, "bottom", placements[i].Side)
+		assert.Equal(t, rot, placements[i].Rot, placements[i].Ref)
+	}
+}
+
+func TestReadPlacementsInvalidRotation(t *testing.T) {
+	_, err := eagle.ReadPlacements(strings.NewReader(`<eagle><drawing><board><elements>
+<element name="R1" package="R0603" x="0" y="0" rot="X90"/>
+</elements></board></drawing></eagle>`))
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package horizon
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+)
+
+func init() {
+	bom.RegisterReader(&bomReader{})
+}
+
+// lcscPartNumber matches LCSC part numbers (eg. "C25804").
+var lcscPartNumber = regexp.MustCompile(`^C\d+$`)
+
+// bomRow is a single row in a Horizon EDA BOM (using the default column names).
+type bomRow struct {
+	Qty          int             `csv:"QTY"`
+	MPN          string          `csv:"MPN"`
+	Value        string          `csv:"Value"`
+	Manufacturer string          `csv:"Manufacturer"`
+	Refdes       bom.Designators `csv:"Refdes"`
+	Package      string          `csv:"Package"`
+	LCSC         string          `csv:"LCSC"`
+}
+
+// ReadBOM reads a Horizon EDA BOM. The LCSC part number is taken from an "LCSC"
+// column, or the MPN if the part's manufacturer is LCSC (or the MPN looks like
+// an LCSC part number).
+func ReadBOM(r io.Reader) ([]bom.Entry, error) {
+	rows, err := csvx.Unmarshal[bomRow](r)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+
+	entries := make([]bom.Entry, 0, len(rows))
+	for _, row := range rows {
+		lcsc := row.LCSC
+		if lcsc == "" && (row.Manufacturer == "LCSC" || lcscPartNumber.MatchString(row.MPN)) {
+			lcsc = row.MPN
+		}
+
+		entries = append(entries, bom.Entry{
+			Reference: row.Refdes,
+			Value:     row.Value,
+			Footprint: row.Package,
+			Qty:       row.Qty,
+			LCSC:      lcsc,
+		})
+	}
+
+	return entries, nil
+}
+
+// bomReader reads Horizon EDA BOMs.
+type bomReader struct{}
+
+func (r *bomReader) Name() string {
+	return "horizon"
+}
+
+func (r *bomReader) Sniff(name string, head []byte) bool {
+	return csvx.HasHeader(head, 0, "QTY", "Refdes")
+}
+
+func (r *bomReader) Read(rd io.Reader) ([]bom.Entry, error) {
+	return ReadBOM(rd)
+}
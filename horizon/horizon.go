@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package horizon reads Horizon EDA pick and place files and BOMs.
+package horizon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// length is a length in millimetres, optionally with a "mm" suffix.
+type length float64
+
+func (l *length) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(string(text)), "mm"))
+	if s == "" {
+		*l = 0
+		return nil
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid length: %q", text)
+	}
+
+	*l = length(n)
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package horizon
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+)
+
+func init() {
+	placement.RegisterReader(&placementReader{})
+}
+
+// placementRow is a single row in a Horizon EDA pick and place file (using the
+// default column names).
+type placementRow struct {
+	Ref     string  `csv:"Ref"`
+	Value   string  `csv:"Value"`
+	Package string  `csv:"Package"`
+	X       length  `csv:"X"`
+	Y       length  `csv:"Y"`
+	Angle   float64 `csv:"Angle"`
+	Side    string  `csv:"Side"`
+}
+
+// ReadPlacements reads the component placements from a Horizon EDA pick and
+// place file.
+func ReadPlacements(r io.Reader) ([]placement.Placement, error) {
+	rows, err := csvx.Unmarshal[placementRow](r)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+
+	placements := make([]placement.Placement, 0, len(rows))
+	for _, row := range rows {
+		side := strings.ToLower(row.Side)
+		if side != "top" && side != "bottom" {
+			return nil, fmt.Errorf("invalid side for %s: %q", row.Ref, row.Side)
+		}
+
+		placements = append(placements, placement.Placement{
+			Ref:     row.Ref,
+			Val:     row.Value,
+			Package: row.Package,
+			PosX:    float64(row.X),
+			PosY:    float64(row.Y),
+			Rot:     row.Angle,
+			Side:    side,
+		})
+	}
+
+	return placements, nil
+}
+
+// placementReader reads Horizon EDA pick and place files.
+type placementReader struct{}
+
+func (r *placementReader) Name() string {
+	return "horizon"
+}
+
+func (r *placementReader) Sniff(name string, head []byte) bool {
+	return csvx.HasHeader(head, 0, "Ref", "X", "Y", "Angle", "Side")
+}
+
+func (r *placementReader) Read(rd io.Reader) ([]placement.Placement, error) {
+	return ReadPlacements(rd)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package horizon_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/horizon"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPlacements(t *testing.T) {
+	f, err := os.Open("testdata/pnp.csv")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	placements, err := horizon.ReadPlacements(f)
+	require.NoError(t, err)
+
+	expected := []placement.Placement{
+		{Ref: "C1", Val: "100n", Package: "C0603", PosX: 10.16, PosY: 20.32, Rot: 90, Side: "top"},
+		{Ref: "R1", Val: "10k", Package: "R0603", PosX: 12.7, PosY: 20.32, Rot: 180, Side: "bottom"},
+		{Ref: "U1", Val: "AP2112K-3.3", Package: "SOT-23-5", PosX: 25.4, PosY: 15.24, Rot: 270, Side: "top"},
+	}
+
+	assert.Equal(t, expected, placements)
+}
+
+func TestLoadPlacements(t *testing.T) {
+	placements, format, err := placement.Load("testdata/pnp.csv", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "horizon", format)
+	assert.Len(t, placements, 3)
+}
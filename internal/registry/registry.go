@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package registry implements the format registries shared by the BOM and
+// component placement readers.
+package registry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SniffLen is how much of a file is passed to Reader.Sniff.
+const SniffLen = 4096
+
+// Reader reads records (eg. BOM entries) from a particular EDA tool's output.
+type Reader[T any] interface {
+	// Name is the short name of the format (eg. "kicad").
+	Name() string
+	// Sniff reports whether a file is in this format, based on its name and
+	// the first few kilobytes of its content.
+	Sniff(name string, head []byte) bool
+	// Read reads all the records from r.
+	Read(r io.Reader) ([]T, error)
+}
+
+// Registry is a set of readers, sniffed in the order they were registered.
+type Registry[T any] struct {
+	// kind describes what is read (eg. "BOM"), for error messages.
+	kind    string
+	mu      sync.RWMutex
+	readers []Reader[T]
+}
+
+// New creates an empty registry for readers of the given kind of file.
+func New[T any](kind string) *Registry[T] {
+	return &Registry[T]{kind: kind}
+}
+
+// Register registers a reader. Reader names must be unique.
+func (reg *Registry[T]) Register(r Reader[T]) error {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	for _, existing := range reg.readers {
+		if existing.Name() == r.Name() {
+			return fmt.Errorf("%s reader %s is already registered", reg.kind, r.Name())
+		}
+	}
+
+	reg.readers = append(reg.readers, r)
+
+	return nil
+}
+
+// Readers returns all the registered readers.
+func (reg *Registry[T]) Readers() []Reader[T] {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	return append([]Reader[T]{}, reg.readers...)
+}
+
+// Lookup finds a registered reader by name.
+func (reg *Registry[T]) Lookup(name string) (Reader[T], bool) {
+	for _, r := range reg.Readers() {
+		if r.Name() == name {
+			return r, true
+		}
+	}
+
+	return nil, false
+}
+
+// Load loads records from a file. If format is empty, it will be detected from
+// the file's name and content. The name of the format is returned alongside the
+// records.
+func (reg *Registry[T]) Load(path, format string) ([]T, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReaderSize(f, SniffLen)
+
+	var reader Reader[T]
+	if format != "" {
+		var ok bool
+		reader, ok = reg.Lookup(format)
+		if !ok {
+			return nil, "", fmt.Errorf("unknown format: %s", format)
+		}
+	} else {
+		head, err := br.Peek(SniffLen)
+		if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+			return nil, "", fmt.Errorf("could not read file: %w", err)
+		}
+
+		for _, r := range reg.Readers() {
+			if r.Sniff(filepath.Base(path), head) {
+				reader = r
+				break
+			}
+		}
+
+		if reader == nil {
+			return nil, "", fmt.Errorf("unrecognized %s format", reg.kind)
+		}
+	}
+
+	records, err := reader.Read(br)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read %s %s: %w", reader.Name(), reg.kind, err)
+	}
+
+	return records, reader.Name(), nil
+}
+
+// ReadFile reads a file using a specific reader (eg. one configured with reader
+// specific options), bypassing format detection.
+func (reg *Registry[T]) ReadFile(path string, reader Reader[T]) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	records, err := reader.Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s %s: %w", reader.Name(), reg.kind, err)
+	}
+
+	return records, nil
+}
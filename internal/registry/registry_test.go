@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package registry_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/internal/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReader reads files starting with its prefix, returning the rest of the
+// file as a single record.
+type testReader struct {
+	name   string
+	prefix string
+}
+
+func (r *testReader) Name() string {
+	return r.name
+}
+
+func (r *testReader) Sniff(name string, head []byte) bool {
+	return bytes.HasPrefix(head, []byte(r.prefix))
+}
+
+func (r *testReader) Read(rd io.Reader) ([]string, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	rest, ok := bytes.CutPrefix(data, []byte(r.prefix))
+	if !ok {
+		return nil, fmt.Errorf("missing prefix")
+	}
+
+	return []string{string(rest)}, nil
+}
+
+func TestRegister(t *testing.T) {
+	reg := registry.New[string]("test")
+
+	require.NoError(t, reg.Register(&testReader{name: "a", prefix: "A"}))
+	require.NoError(t, reg.Register(&testReader{name: "b", prefix: "B"}))
+	assert.Error(t, reg.Register(&testReader{name: "a", prefix: "C"}))
+
+	require.Len(t, reg.Readers(), 2)
+
+	r, ok := reg.Lookup("b")
+	require.True(t, ok)
+	assert.Equal(t, "b", r.Name())
+
+	_, ok = reg.Lookup("c")
+	assert.False(t, ok)
+}
+
+func TestLoad(t *testing.T) {
+	reg := registry.New[string]("test")
+	require.NoError(t, reg.Register(&testReader{name: "a", prefix: "A"}))
+	require.NoError(t, reg.Register(&testReader{name: "b", prefix: "B"}))
+
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte("Bhello"), 0o644))
+
+	records, format, err := reg.Load(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "b", format)
+	assert.Equal(t, []string{"hello"}, records)
+
+	_, _, err = reg.Load(path, "a")
+	assert.ErrorContains(t, err, "could not read a test")
+
+	_, _, err = reg.Load(path, "c")
+	assert.ErrorContains(t, err, "unknown format")
+
+	require.NoError(t, os.WriteFile(path, []byte("Chello"), 0o644))
+
+	_, _, err = reg.Load(path, "")
+	assert.ErrorContains(t, err, "unrecognized test format")
+}
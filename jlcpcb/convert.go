@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb
+
+import (
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// BOMEntry is a single line item in a JLCPCB BOM.
+type BOMEntry struct {
+	Comment    string `csv:"Comment" json:"comment" yaml:"comment"`
+	Designator string `csv:"Designator" json:"designator" yaml:"designator"`
+	Footprint  string `csv:"Footprint" json:"footprint" yaml:"footprint"`
+	LCSC       string `csv:"LCSC Part Number" json:"lcsc" yaml:"lcsc"`
+	// Source is the original BOM entry.
+	Source bom.Entry `csv:"-" json:"source" yaml:"source"`
+}
+
+// PlacementEntry is a single component placement in a JLCPCB CPL.
+type PlacementEntry struct {
+	Designator string  `csv:"Designator" json:"designator" yaml:"designator"`
+	MidX       float64 `csv:"Mid X" json:"mid_x" yaml:"mid_x"`
+	MidY       float64 `csv:"Mid Y" json:"mid_y" yaml:"mid_y"`
+	Layer      string  `csv:"Layer" json:"layer" yaml:"layer"`
+	Rotation   float64 `csv:"Rotation" json:"rotation" yaml:"rotation"`
+	// Source is the original (uncorrected) placement.
+	Source placement.Placement `csv:"-" json:"source" yaml:"source"`
+	// Correction is the rotation correction that was applied (if any).
+	Correction *RotationCorrection `csv:"-" json:"correction,omitempty" yaml:"correction,omitempty"`
+}
+
+// ConvertBOM converts BOM entries into JLCPCB format, mapping footprints onto
+// JLCPCB package names (see MapFootprint).
+func ConvertBOM(entries []bom.Entry, footprintOverrides FootprintTable) []BOMEntry {
+	converted := make([]BOMEntry, 0, len(entries))
+	for _, entry := range entries {
+		converted = append(converted, BOMEntry{
+			Comment:    entry.Value,
+			Designator: entry.Reference.String(),
+			Footprint:  MapFootprint(entry.Footprint, footprintOverrides),
+			LCSC:       entry.LCSC,
+			Source:     entry,
+		})
+	}
+
+	return converted
+}
+
+// ConvertPlacements converts component placements into JLCPCB format, fixing up
+// the differences between the EDA tool's and JLCPCB's rotations/placements using
+// the given rotation tables (see FindRotationCorrection).
+func ConvertPlacements(placements []placement.Placement, rotations ...RotationTable) []PlacementEntry {
+	titleCaser := cases.Title(language.English, cases.Compact)
+
+	converted := make([]PlacementEntry, 0, len(placements))
+	for _, p := range placements {
+		corrected := &p
+
+		correction, ok := FindRotationCorrection(p, rotations...)
+		if ok {
+			corrected = correction.Apply(p)
+		}
+
+		converted = append(converted, PlacementEntry{
+			Designator: corrected.Ref,
+			MidX:       corrected.PosX,
+			MidY:       corrected.PosY,
+			Layer:      titleCaser.String(corrected.Side),
+			Rotation:   corrected.Rot,
+			Source:     p,
+			Correction: correction,
+		})
+	}
+
+	return converted
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+)
+
+// FootprintMapping maps KiCad footprint names onto JLCPCB package names.
+// The package name may reference capture groups from the footprint pattern
+// (eg. "${1}").
+type FootprintMapping struct {
+	FootprintPattern UnmarshallableRegexp `csv:"Footprint pattern" json:"footprint_pattern" yaml:"footprint_pattern" toml:"footprint_pattern"`
+	Package          string               `csv:"Package" json:"package" yaml:"package" toml:"package"`
+}
+
+// FootprintTable is a set of footprint mappings.
+type FootprintTable []FootprintMapping
+
+//go:embed kicad_footprints.csv
+var footprintDBData []byte
+
+var footprintDB FootprintTable
+
+func init() {
+	var err error
+	footprintDB, err = LoadFootprintTable(bytes.NewReader(footprintDBData))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// LoadFootprintTable loads a footprint mapping table from CSV.
+func LoadFootprintTable(r io.Reader) (FootprintTable, error) {
+	mappings, err := csvx.Unmarshal[FootprintMapping](r)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse footprint mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// MapFootprint converts a KiCad footprint (with or without the library prefix)
+// into a JLCPCB package name. The overrides are consulted before the built-in
+// table. If nothing matches, the footprint name is returned without its library
+// prefix.
+func MapFootprint(footprint string, overrides FootprintTable) string {
+	// JLCPCB has no use for the KiCad library nickname.
+	if _, name, ok := strings.Cut(footprint, ":"); ok {
+		footprint = name
+	}
+
+	if pkg, ok := overrides.lookup(footprint); ok {
+		return pkg
+	}
+
+	if pkg, ok := footprintDB.lookup(footprint); ok {
+		return pkg
+	}
+
+	return footprint
+}
+
+// lookup finds the most specific mapping for a footprint name.
+func (t FootprintTable) lookup(footprint string) (string, bool) {
+	var matches []FootprintMapping
+	for _, mapping := range t {
+		if mapping.FootprintPattern.Regexp == nil {
+			continue
+		}
+		if !mapping.FootprintPattern.MatchString(footprint) {
+			continue
+		}
+
+		matches = append(matches, mapping)
+	}
+
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	// Pick most specific match
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].FootprintPattern.Length > matches[j].FootprintPattern.Length
+	})
+
+	mapping := matches[0]
+
+	submatches := mapping.FootprintPattern.FindStringSubmatchIndex(footprint)
+	pkg := mapping.FootprintPattern.ExpandString(nil, mapping.Package, footprint, submatches)
+
+	return string(pkg), true
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFootprint(t *testing.T) {
+	assert.Equal(t, "0603", jlcpcb.MapFootprint("Capacitor_SMD:C_0603_1608Metric", nil))
+	assert.Equal(t, "0402", jlcpcb.MapFootprint("R_0402_1005Metric", nil))
+	assert.Equal(t, "SMA(DO-214AC)", jlcpcb.MapFootprint("Diode_SMD:D_SMA", nil))
+	assert.Equal(t, "SOT-23-5", jlcpcb.MapFootprint("Package_TO_SOT_SMD:SOT-23-5", nil))
+	assert.Equal(t, "QFN-20-EP(3x3)", jlcpcb.MapFootprint("Package_DFN_QFN:QFN-20-1EP_3x3mm_P0.4mm_EP1.65x1.65mm", nil))
+}
+
+func TestMapFootprintNoMatch(t *testing.T) {
+	assert.Equal(t, "PinHeader_1x03_P2.54mm_Vertical",
+		jlcpcb.MapFootprint("Connector_PinHeader_2.54mm:PinHeader_1x03_P2.54mm_Vertical", nil))
+}
+
+func TestMapFootprintOverrides(t *testing.T) {
+	overrides, err := jlcpcb.LoadFootprintTable(strings.NewReader(`"Footprint pattern","Package"
+"^C_0603_1608Metric$","C0603"
+"^PinHeader_1x(\d+)_P2\.54mm_Vertical$","HDR-1x${1}"
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "C0603", jlcpcb.MapFootprint("Capacitor_SMD:C_0603_1608Metric", overrides))
+	assert.Equal(t, "HDR-1x03", jlcpcb.MapFootprint("Connector_PinHeader_2.54mm:PinHeader_1x03_P2.54mm_Vertical", overrides))
+	// Footprints not covered by the overrides fall back to the built-in table.
+	assert.Equal(t, "0603", jlcpcb.MapFootprint("Resistor_SMD:R_0603_1608Metric", overrides))
+}
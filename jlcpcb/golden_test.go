@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/dpeckett/jlcfabtool/altium"
+	"github.com/dpeckett/jlcfabtool/csvx"
+	_ "github.com/dpeckett/jlcfabtool/eagle"
+	_ "github.com/dpeckett/jlcfabtool/horizon"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	_ "github.com/dpeckett/jlcfabtool/librepcb"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the snapshots of the golden tests, eg.
+//
+//	go test ./jlcpcb -run TestGolden -update
+//
+// The test still fails after rewriting a snapshot, listing what changed, so
+// each change can be checked before it is committed.
+var update = flag.Bool("update", false, "rewrite the golden snapshots (the test still fails, listing the changes)")
+
+const (
+	// goldenDir holds a directory per board, with the board's component
+	// placements (in any input format) and a snapshot of the converted output.
+	goldenDir = "testdata/golden"
+	// goldenFile is the name of the snapshot. Snapshots record earlier output
+	// of ConvertPlacements, they are not verified against JLCPCB orders.
+	goldenFile = "snapshot.csv"
+
+	goldenPositionTolerance = 0.001
+	goldenRotationTolerance = 0.01
+)
+
+// goldenEntry is a row of a golden file.
+type goldenEntry struct {
+	Designator string  `csv:"Designator"`
+	MidX       float64 `csv:"Mid X"`
+	MidY       float64 `csv:"Mid Y"`
+	Layer      string  `csv:"Layer"`
+	Rotation   float64 `csv:"Rotation"`
+}
+
+// TestGolden converts the component placements of real boards with the
+// built-in rotation corrections, and compares them against snapshots of earlier
+// output. A difference means the conversion changed, not that it is wrong (or
+// that the snapshot was right), so each change needs checking against a JLCPCB
+// order preview.
+func TestGolden(t *testing.T) {
+	boards, err := os.ReadDir(goldenDir)
+	require.NoError(t, err)
+
+	for _, board := range boards {
+		if !board.IsDir() {
+			continue
+		}
+
+		t.Run(board.Name(), func(t *testing.T) {
+			dir := filepath.Join(goldenDir, board.Name())
+
+			input := goldenInput(t, dir)
+
+			placements, format, err := placement.Load(input, "")
+			require.NoError(t, err)
+
+			rotations, ok := jlcpcb.RotationTableFor(format)
+			require.True(t, ok, "no rotation corrections for format %s", format)
+
+			actual := toGolden(jlcpcb.ConvertPlacements(placements, rotations))
+
+			path := filepath.Join(dir, goldenFile)
+
+			var expected []goldenEntry
+			if data, err := os.ReadFile(path); err == nil {
+				expected, err = csvx.Unmarshal[goldenEntry](bytes.NewReader(data))
+				require.NoError(t, err)
+			} else if !os.IsNotExist(err) || !*update {
+				require.NoError(t, err, "missing snapshot (run with -update to create it)")
+			}
+
+			diffs := diffGolden(expected, actual, placements)
+
+			if *update {
+				var buf bytes.Buffer
+				require.NoError(t, csvx.Marshal(&buf, actual))
+				require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+
+				if len(diffs) > 0 {
+					t.Errorf("rewrote %s, %d placements changed (check each against a JLCPCB order preview before committing):\n%s",
+						path, len(diffs), strings.Join(diffs, "\n"))
+				}
+				return
+			}
+
+			if len(diffs) > 0 {
+				t.Errorf("%d placements differ from the snapshot %s. Snapshots are not verified JLCPCB output, "+
+					"check each change against a JLCPCB order preview before rewriting it with -update:\n%s",
+					len(diffs), path, strings.Join(diffs, "\n"))
+			}
+		})
+	}
+}
+
+// goldenInput finds the component placement file of a board.
+func goldenInput(t *testing.T, dir string) string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var inputs []string
+	for _, entry := range entries {
+		if !entry.IsDir() && entry.Name() != goldenFile {
+			inputs = append(inputs, filepath.Join(dir, entry.Name()))
+		}
+	}
+	require.Len(t, inputs, 1, "expected a single component placement file in %s", dir)
+
+	return inputs[0]
+}
+
+func toGolden(converted []jlcpcb.PlacementEntry) []goldenEntry {
+	entries := make([]goldenEntry, 0, len(converted))
+	for _, entry := range converted {
+		entries = append(entries, goldenEntry{
+			Designator: entry.Designator,
+			MidX:       round(entry.MidX),
+			MidY:       round(entry.MidY),
+			Layer:      entry.Layer,
+			Rotation:   round(entry.Rotation),
+		})
+	}
+
+	return entries
+}
+
+// diffGolden describes the differences between the expected and actual outputs,
+// in the order of the input placements.
+func diffGolden(expected, actual []goldenEntry, placements []placement.Placement) []string {
+	expectedByRef := make(map[string]goldenEntry, len(expected))
+	for _, entry := range expected {
+		expectedByRef[entry.Designator] = entry
+	}
+
+	var diffs []string
+	for i, got := range actual {
+		p := placements[i]
+
+		want, ok := expectedByRef[got.Designator]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("  + %s (%s): %s", got.Designator, p.Package, describeGolden(got)))
+			continue
+		}
+		delete(expectedByRef, got.Designator)
+
+		if math.Abs(want.MidX-got.MidX) > goldenPositionTolerance ||
+			math.Abs(want.MidY-got.MidY) > goldenPositionTolerance ||
+			math.Abs(angleDifference(want.Rotation, got.Rotation)) > goldenRotationTolerance ||
+			want.Layer != got.Layer {
+			diffs = append(diffs, fmt.Sprintf("  ~ %s (%s): expected %s, got %s",
+				got.Designator, p.Package, describeGolden(want), describeGolden(got)))
+		}
+	}
+
+	for _, want := range expected {
+		if _, ok := expectedByRef[want.Designator]; ok {
+			diffs = append(diffs, fmt.Sprintf("  - %s: %s", want.Designator, describeGolden(want)))
+		}
+	}
+
+	return diffs
+}
+
+func describeGolden(e goldenEntry) string {
+	return fmt.Sprintf("(%g, %g) %g° %s", e.MidX, e.MidY, e.Rotation, e.Layer)
+}
+
+// angleDifference returns the smallest difference between two angles (in degrees).
+func angleDifference(a, b float64) float64 {
+	d := math.Mod(a-b, 360)
+	if d > 180 {
+		d -= 360
+	} else if d < -180 {
+		d += 360
+	}
+	return d
+}
+
+func round(v float64) float64 {
+	v = math.Round(v*10000) / 10000
+	if v == 0 {
+		return 0 // Avoid "-0"
+	}
+	return v
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+)
+
+// importColumns maps the column names used by other rotation correction tables
+// (eg. the legacy "Footprint pattern" schema, or the tables of the JLCPCB KiCad
+// plugins) to the columns of a RotationCorrection.
+var importColumns = map[string][]string{
+	"Package pattern": {"package pattern", "footprint pattern", "footprint", "package", "pattern", "regex", "regexp"},
+	"Value pattern":   {"value pattern", "value"},
+	"Rotation":        {"rotation", "correction", "rotation correction", "rotation offset", "rot", "angle"},
+	"Center X":        {"center x", "offset x", "x offset", "delta x", "dx"},
+	"Center Y":        {"center y", "offset y", "y offset", "delta y", "dy"},
+}
+
+// positionalColumns is the column order of tables without a header (eg. the
+// "pattern,rotation" lines of rotations.cf).
+var positionalColumns = []string{"Package pattern", "Rotation", "Center X", "Center Y"}
+
+// ImportRotationTable converts a rotation correction table in another schema
+// (as CSV) into a RotationTable. The columns are matched by name (see
+// importColumns), or by position if the table has no header. Rotations are
+// normalized to (-180, 180] and an empty rotation means no rotation (eg. for
+// rules that only adjust the center). Rows that can't be converted are skipped
+// and reported as issues.
+func ImportRotationTable(r io.Reader) (RotationTable, []LintIssue, error) {
+	dec := csvx.NewDecoder[struct{}](r, csvx.WithComment('#'))
+
+	header, err := dec.Header()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read rotation corrections: %w", err)
+	}
+
+	columns := importColumnIndexes(header)
+
+	var table RotationTable
+	var issues []LintIssue
+
+	if _, ok := columns["Package pattern"]; !ok {
+		// No recognizable header, so the first row is a rule.
+		if len(header) < 2 {
+			return nil, nil, errors.New("could not find the package pattern and rotation columns")
+		}
+
+		columns = make(map[string]int, len(positionalColumns))
+		for i, column := range positionalColumns {
+			columns[column] = i
+		}
+
+		correction, err := importRule(header, columns)
+		if err != nil {
+			issues = append(issues, LintIssue{Line: dec.Line(), Message: err.Error()})
+		} else {
+			table = append(table, correction)
+		}
+	} else if _, ok := columns["Rotation"]; !ok {
+		return nil, nil, errors.New("could not find the rotation column")
+	}
+
+	for {
+		record, err := dec.Record()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			issues = append(issues, rowIssue(dec.Line(), err))
+			continue
+		}
+
+		correction, err := importRule(record, columns)
+		if err != nil {
+			issues = append(issues, LintIssue{Line: dec.Line(), Message: err.Error()})
+			continue
+		}
+
+		table = append(table, correction)
+	}
+
+	return table, issues, nil
+}
+
+// RotationConflict is a rule that was left out when merging rotation correction
+// tables, because an earlier rule has the same patterns.
+type RotationConflict struct {
+	Kept    RotationCorrection
+	Dropped RotationCorrection
+}
+
+func (c RotationConflict) String() string {
+	return fmt.Sprintf("conflicting rules for %s: kept %s, dropped %s",
+		describeRule(c.Kept), describeCorrection(c.Kept), describeCorrection(c.Dropped))
+}
+
+// MergeRotationTables combines rotation correction tables, in order, removing
+// duplicate rules. If a rule has the same patterns as an earlier rule but a
+// different correction the earlier rule is kept, and the conflict is reported.
+func MergeRotationTables(tables ...RotationTable) (RotationTable, []RotationConflict) {
+	var merged RotationTable
+	var conflicts []RotationConflict
+
+	seen := make(map[string]int)
+	for _, table := range tables {
+		for _, correction := range table {
+			key := correction.PackagePattern.String() + "\x00" + correction.ValuePattern.String()
+
+			i, ok := seen[key]
+			if !ok {
+				seen[key] = len(merged)
+				merged = append(merged, correction)
+				continue
+			}
+
+			if !sameCorrection(merged[i], correction) {
+				conflicts = append(conflicts, RotationConflict{Kept: merged[i], Dropped: correction})
+			}
+		}
+	}
+
+	return merged, conflicts
+}
+
+// WriteRotationTable writes a rotation correction table as CSV.
+func WriteRotationTable(w io.Writer, table RotationTable) error {
+	if err := csvx.Marshal(w, table); err != nil {
+		return fmt.Errorf("could not write rotation corrections: %w", err)
+	}
+
+	return nil
+}
+
+// importColumnIndexes finds the known columns in a header. If several columns
+// match, the earliest alias wins (eg. "Package pattern" over "Footprint").
+func importColumnIndexes(header []string) map[string]int {
+	columns := make(map[string]int)
+	priorities := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+
+		for column, aliases := range importColumns {
+			for priority, alias := range aliases {
+				if name != alias {
+					continue
+				}
+
+				if current, ok := priorities[column]; !ok || priority < current {
+					columns[column] = i
+					priorities[column] = priority
+				}
+				break
+			}
+		}
+	}
+
+	return columns
+}
+
+// importRule converts a row into a rotation correction.
+func importRule(record []string, columns map[string]int) (RotationCorrection, error) {
+	cell := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var correction RotationCorrection
+
+	pattern := cell("Package pattern")
+	if pattern == "" {
+		return correction, errors.New("rule has no package pattern")
+	}
+	if err := correction.PackagePattern.UnmarshalText([]byte(pattern)); err != nil {
+		return correction, fmt.Errorf("invalid package pattern: %w", err)
+	}
+	if err := correction.ValuePattern.UnmarshalText([]byte(cell("Value pattern"))); err != nil {
+		return correction, fmt.Errorf("invalid value pattern: %w", err)
+	}
+
+	if rotation := cell("Rotation"); rotation != "" {
+		v, err := strconv.ParseFloat(rotation, 64)
+		if err != nil {
+			return correction, fmt.Errorf("invalid rotation: %w", err)
+		}
+		correction.Rotation = normalizeRotation(v)
+	}
+
+	var err error
+	if correction.CenterX, err = importOffset(cell("Center X")); err != nil {
+		return correction, fmt.Errorf("invalid center X: %w", err)
+	}
+	if correction.CenterY, err = importOffset(cell("Center Y")); err != nil {
+		return correction, fmt.Errorf("invalid center Y: %w", err)
+	}
+
+	return correction, nil
+}
+
+// importOffset parses an optional center offset.
+func importOffset(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// normalizeRotation normalizes a rotation to (-180, 180] (eg. 270 becomes -90).
+func normalizeRotation(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle > 180 {
+		angle -= 360
+	} else if angle <= -180 {
+		angle += 360
+	}
+
+	return angle
+}
+
+func describeRule(c RotationCorrection) string {
+	if c.ValuePattern.Regexp == nil {
+		return fmt.Sprintf("%q", c.PackagePattern.String())
+	}
+
+	return fmt.Sprintf("%q (value %q)", c.PackagePattern.String(), c.ValuePattern.String())
+}
+
+func describeCorrection(c RotationCorrection) string {
+	s := strconv.FormatFloat(c.Rotation, 'g', -1, 64) + "°"
+	if c.CenterX != nil || c.CenterY != nil {
+		s += fmt.Sprintf(" (center %g, %g)", valueOrZero(c.CenterX), valueOrZero(c.CenterY))
+	}
+
+	return s
+}
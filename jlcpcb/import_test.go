@@ -0,0 +1,175 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportRotationTable(t *testing.T) {
+	f, err := os.Open("testdata/legacy_rotations.csv")
+	require.NoError(t, err)
+	defer f.Close()
+
+	table, issues, err := jlcpcb.ImportRotationTable(f)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+	assert.Equal(t, []string{
+		"line 6: invalid package pattern: error parsing regexp: missing closing ): `^SOT-23(-5$`",
+		"line 7: rule has no package pattern",
+	}, lines)
+
+	require.Len(t, table, 4)
+
+	assert.Equal(t, "^IDC-Header_.+Vertical$", table[0].PackagePattern.String())
+	assert.Nil(t, table[0].ValuePattern.Regexp)
+	assert.Equal(t, -90.0, table[0].Rotation)
+	require.NotNil(t, table[0].CenterX)
+	assert.Equal(t, 1.25, *table[0].CenterX)
+	require.NotNil(t, table[0].CenterY)
+	assert.Equal(t, -11.55, *table[0].CenterY)
+
+	// An empty rotation only adjusts the center.
+	assert.Equal(t, "^USB_C_Receptacle.+16P", table[3].PackagePattern.String())
+	assert.Equal(t, 0.0, table[3].Rotation)
+	assert.Nil(t, table[3].CenterX)
+	require.NotNil(t, table[3].CenterY)
+	assert.Equal(t, 1.3, *table[3].CenterY)
+}
+
+func TestImportRotationTableThirdParty(t *testing.T) {
+	t.Run("Correction", func(t *testing.T) {
+		table, issues, err := jlcpcb.ImportRotationTable(strings.NewReader(`"Footprint pattern","Correction"
+"^SOT-223",180
+"^QFN-",270
+`))
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		require.Len(t, table, 2)
+		assert.Equal(t, "^SOT-223", table[0].PackagePattern.String())
+		assert.Equal(t, 180.0, table[0].Rotation)
+		assert.Equal(t, "^QFN-", table[1].PackagePattern.String())
+		assert.Equal(t, -90.0, table[1].Rotation)
+	})
+
+	t.Run("Offsets", func(t *testing.T) {
+		table, issues, err := jlcpcb.ImportRotationTable(strings.NewReader(`Regex;Rotation;Offset X;Offset Y
+^PinHeader_1x03_P2.54mm_Vertical$;-90;0;-2.5
+`))
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		require.Len(t, table, 1)
+		assert.Equal(t, -90.0, table[0].Rotation)
+		require.NotNil(t, table[0].CenterX)
+		assert.Equal(t, 0.0, *table[0].CenterX)
+		require.NotNil(t, table[0].CenterY)
+		assert.Equal(t, -2.5, *table[0].CenterY)
+	})
+
+	t.Run("No Header", func(t *testing.T) {
+		table, issues, err := jlcpcb.ImportRotationTable(strings.NewReader(`# rotations.cf
+^SOT-23,180
+^TSSOP-,-90
+`))
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		require.Len(t, table, 2)
+		assert.Equal(t, "^SOT-23", table[0].PackagePattern.String())
+		assert.Equal(t, 180.0, table[0].Rotation)
+		assert.Equal(t, "^TSSOP-", table[1].PackagePattern.String())
+		assert.Equal(t, -90.0, table[1].Rotation)
+	})
+
+	t.Run("Column Priority", func(t *testing.T) {
+		// The canonical column names win over aliases, wherever they are (eg.
+		// for the proposals written by "rotations infer").
+		table, issues, err := jlcpcb.ImportRotationTable(strings.NewReader(`"Footprint","Rotation","Package pattern","Correction"
+"SOIC-8_3.9x4.9mm_P1.27mm",-90,"^SOIC-8_3\.9x4\.9mm_P1\.27mm$",180
+`))
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		require.Len(t, table, 1)
+		assert.Equal(t, `^SOIC-8_3\.9x4\.9mm_P1\.27mm$`, table[0].PackagePattern.String())
+		assert.Equal(t, -90.0, table[0].Rotation)
+	})
+
+	t.Run("Missing Rotation", func(t *testing.T) {
+		_, _, err := jlcpcb.ImportRotationTable(strings.NewReader(`"Footprint pattern","Comment"
+"^SOT-223","Regulator"
+`))
+		require.Error(t, err)
+	})
+}
+
+func TestMergeRotationTables(t *testing.T) {
+	existing, err := jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^SOT-23-5$","",-90,,
+"^WQFN-20-1EP_3x3mm_P0.4mm_EP1.7x1.7mm$","^ADS131M02IRUKR$",90,,
+`))
+	require.NoError(t, err)
+
+	imported, _, err := jlcpcb.ImportRotationTable(strings.NewReader(`"Footprint pattern","Rotation"
+"^SOT-23-5$",180
+"^SOT-23$",180
+"^SOT-23$",-180
+"^WQFN-20-1EP_3x3mm_P0.4mm_EP1.7x1.7mm$",-90
+`))
+	require.NoError(t, err)
+
+	merged, conflicts := jlcpcb.MergeRotationTables(existing, imported)
+
+	var patterns []string
+	for _, correction := range merged {
+		patterns = append(patterns, correction.PackagePattern.String())
+	}
+	assert.Equal(t, []string{
+		"^SOT-23-5$",
+		"^WQFN-20-1EP_3x3mm_P0.4mm_EP1.7x1.7mm$",
+		"^SOT-23$",
+		"^WQFN-20-1EP_3x3mm_P0.4mm_EP1.7x1.7mm$",
+	}, patterns)
+
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, -90.0, conflicts[0].Kept.Rotation)
+	assert.Equal(t, 180.0, conflicts[0].Dropped.Rotation)
+	assert.Equal(t, `conflicting rules for "^SOT-23-5$": kept -90°, dropped 180°`, conflicts[0].String())
+
+	var buf bytes.Buffer
+	require.NoError(t, jlcpcb.WriteRotationTable(&buf, merged))
+
+	roundTrip, err := jlcpcb.LoadRotationTable(&buf)
+	require.NoError(t, err)
+	require.Len(t, roundTrip, len(merged))
+	assert.Equal(t, "^ADS131M02IRUKR$", roundTrip[1].ValuePattern.String())
+	assert.Equal(t, 90.0, roundTrip[1].Rotation)
+}
@@ -0,0 +1,399 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+
+	"github.com/dpeckett/jlcfabtool/kicad/footprint"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+)
+
+// The classes of package used to infer the zero orientation of a footprint.
+const (
+	// ClassInline is a single row of pads (including two terminal parts).
+	ClassInline = "inline"
+	// ClassDualRow is two rows of pads (eg. SOIC, SOT-23-5).
+	ClassDualRow = "dual-row"
+	// ClassTab is a row of pads opposite a single pad (eg. SOT-23, SOT-223).
+	ClassTab = "tab"
+	// ClassQuad is pads on all four sides, or a grid of pads (eg. QFN, BGA).
+	ClassQuad = "quad"
+)
+
+// centroidTolerance is the distance (in mm) below which a center offset is
+// considered to agree with the centroid of a footprint.
+const centroidTolerance = 0.1
+
+// padTolerance is the distance (in mm) within which pads are considered to be
+// in the same row or column.
+const padTolerance = 0.05
+
+// RotationProposal is a rotation correction inferred from the geometry of a
+// footprint. Proposals are meant to be reviewed, they aren't applied.
+type RotationProposal struct {
+	Correction RotationCorrection `csv:",inline" json:"correction" yaml:"correction"`
+	Footprint  string             `csv:"Footprint" json:"footprint" yaml:"footprint"`
+	Class      string             `csv:"Class" json:"class" yaml:"class"`
+	// Pin1 is the location of pin 1 (eg. "upper left").
+	Pin1 string `csv:"Pin 1" json:"pin1" yaml:"pin1"`
+	// Centroid is the geometry the center offset was computed from (eg.
+	// "courtyard"), empty if the footprint's origin is its centroid.
+	Centroid string `csv:"Centroid" json:"centroid,omitempty" yaml:"centroid,omitempty"`
+}
+
+// InferRotation infers the rotation correction of a footprint from its pads.
+// The location of pin 1 (relative to the center of the pads), and the
+// orientation of the rows of pads, are compared to the zero orientation used by
+// JLCPCB (IPC-7351B level B, which follows the EIA-481 tape orientation):
+//
+//   - inline: the pads are horizontal, with pin 1 on the left.
+//   - dual-row: the rows are along the top and bottom, with pin 1 at the lower
+//     left.
+//   - tab: the single pad is on the left, with pin 1 at the bottom of the row of
+//     pads on the right (as in the JLCPCB tables for SOT-23 and SOT-223).
+//   - quad: pin 1 is at the lower left.
+//
+// If the footprint's origin isn't the centroid of its body (eg. connectors with
+// the origin at pin 1), the proposal also offsets the center (see
+// CentroidOffset).
+func InferRotation(fp footprint.Footprint) (*RotationProposal, error) {
+	var pads []footprint.Pad
+	var pin1 []footprint.Pad
+	for _, pad := range fp.Pads {
+		if pad.Number == "" {
+			continue
+		}
+		pads = append(pads, pad)
+
+		if pad.Number == "1" || pad.Number == "A1" {
+			pin1 = append(pin1, pad)
+		}
+	}
+	if len(pads) < 2 {
+		return nil, errors.New("footprint has fewer than two pads")
+	}
+	if len(pin1) == 0 {
+		return nil, errors.New("footprint has no pin 1")
+	}
+
+	// Split pads (eg. pin 1 made of several pads) are located at their center.
+	var x1, y1 float64
+	for _, pad := range pin1 {
+		x1 += pad.X / float64(len(pin1))
+		y1 += pad.Y / float64(len(pin1))
+	}
+
+	minX, minY, maxX, maxY := pads[0].X, pads[0].Y, pads[0].X, pads[0].Y
+	for _, pad := range pads[1:] {
+		minX, maxX = math.Min(minX, pad.X), math.Max(maxX, pad.X)
+		minY, maxY = math.Min(minY, pad.Y), math.Max(maxY, pad.Y)
+	}
+	cx, cy := (minX+maxX)/2, (minY+maxY)/2
+
+	// Exposed (or thermal) pads in the middle of the footprint aren't part of
+	// the rows of pads.
+	var signal []footprint.Pad
+	for _, pad := range pads {
+		if pad.Number != pin1[0].Number &&
+			math.Abs(pad.X-cx) < padTolerance && math.Abs(pad.Y-cy) < padTolerance {
+			continue
+		}
+		signal = append(signal, pad)
+	}
+
+	columns := distinct(signal, func(pad footprint.Pad) float64 { return pad.X })
+	rows := distinct(signal, func(pad footprint.Pad) float64 { return pad.Y })
+
+	// The rotations (counter-clockwise) of the zero orientation that match the
+	// layout of the pads.
+	class := ClassQuad
+	reference := 225.0
+	rotations := []float64{0, 90, 180, 270}
+	switch {
+	case rows == 1:
+		class, reference, rotations = ClassInline, 180, []float64{0, 180}
+	case columns == 1:
+		class, reference, rotations = ClassInline, 180, []float64{90, 270}
+	case rows == 2 && columns > 2, columns == 2 && rows > 2:
+		horizontal := rows == 2
+
+		class, rotations = ClassDualRow, []float64{90, 270}
+		if horizontal {
+			rotations = []float64{0, 180}
+		}
+
+		if hasSinglePadRow(signal, horizontal) {
+			// The rows of the reference are on the left and right.
+			class, reference = ClassTab, 315
+			rotations = []float64{0, 180}
+			if horizontal {
+				rotations = []float64{90, 270}
+			}
+		}
+	}
+
+	// Footprints have the Y axis pointing down.
+	dx, dy := x1-cx, cy-y1
+	if math.Hypot(dx, dy) < padTolerance {
+		return nil, errors.New("pin 1 is in the center of the footprint")
+	}
+	angle := math.Atan2(dy, dx) * (180.0 / math.Pi)
+
+	sort.SliceStable(rotations, func(i, j int) bool {
+		return angleDiff(angle, reference+rotations[i]) < angleDiff(angle, reference+rotations[j])
+	})
+
+	// Pin 1 must be in the quadrant of one of the candidate positions (and
+	// clearly closer to it than the others, eg. not in the middle of a side).
+	best, next := angleDiff(angle, reference+rotations[0]), angleDiff(angle, reference+rotations[1])
+	if best >= 45 || next-best < 10 {
+		return nil, fmt.Errorf("the location of pin 1 (%s) is ambiguous for a %s footprint", describeDirection(angle), class)
+	}
+
+	proposal := &RotationProposal{
+		Footprint: fp.Name,
+		Class:     class,
+		Pin1:      describeDirection(angle),
+	}
+	proposal.Correction.Rotation = normalizeRotation(rotations[0])
+	if err := proposal.Correction.PackagePattern.UnmarshalText([]byte("^" + regexp.QuoteMeta(fp.Name) + "$")); err != nil {
+		return nil, err
+	}
+
+	if x, y, source, ok := CentroidOffset(fp); ok && math.Hypot(x, y) >= centroidTolerance {
+		proposal.Correction.CenterX = &x
+		proposal.Correction.CenterY = &y
+		proposal.Centroid = source
+	}
+
+	return proposal, nil
+}
+
+// ProposeRotations infers rotation corrections for the footprints that don't
+// have a rule in any of the tables (and need a correction). Footprints that the
+// rotation can't be inferred for are reported as warnings.
+func ProposeRotations(footprints []footprint.Footprint, tables ...RotationTable) ([]RotationProposal, []string) {
+	var proposals []RotationProposal
+	var warnings []string
+
+	seen := make(map[string]bool)
+	for _, fp := range footprints {
+		if seen[fp.Name] {
+			continue
+		}
+		seen[fp.Name] = true
+
+		p := placement.Placement{Package: fp.Name, Val: fp.Value}
+
+		var ok bool
+		for _, table := range tables {
+			if _, ok = table.lookup(p); ok {
+				break
+			}
+		}
+		if ok {
+			continue
+		}
+
+		proposal, err := InferRotation(fp)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not infer the rotation of %s: %v", fp.Name, err))
+			continue
+		}
+
+		// A rule that doesn't change anything would only add noise to the table.
+		if proposal.Correction.Rotation == 0 && proposal.Correction.CenterX == nil && proposal.Correction.CenterY == nil {
+			continue
+		}
+
+		proposals = append(proposals, *proposal)
+	}
+
+	return proposals, warnings
+}
+
+// CentroidOffset returns the offset of the centroid of a footprint's body from
+// its origin (see footprint.Footprint.Centroid), as the center offset of a
+// rotation correction (ie. with the Y axis pointing up, so cx = x, cy = -y).
+func CentroidOffset(fp footprint.Footprint) (x, y float64, source string, ok bool) {
+	x, y, source, ok = fp.Centroid()
+	if !ok {
+		return 0, 0, "", false
+	}
+
+	return roundOffset(x), roundOffset(-y), source, true
+}
+
+// CentroidCorrections returns rules that offset the center of the footprints
+// that don't have a rule in any of the tables to the centroid of their geometry
+// (see CentroidOffset), so they can be applied when converting placements.
+// Footprints whose origin is their centroid (within 0.1mm) don't get a rule.
+func CentroidCorrections(footprints []footprint.Footprint, tables ...RotationTable) RotationTable {
+	var corrections RotationTable
+
+	seen := make(map[string]bool)
+	for _, fp := range footprints {
+		if seen[fp.Name] {
+			continue
+		}
+		seen[fp.Name] = true
+
+		p := placement.Placement{Package: fp.Name, Val: fp.Value}
+
+		var ok bool
+		for _, table := range tables {
+			if _, ok = table.lookup(p); ok {
+				break
+			}
+		}
+		if ok {
+			continue
+		}
+
+		x, y, _, ok := CentroidOffset(fp)
+		if !ok || math.Hypot(x, y) < centroidTolerance {
+			continue
+		}
+
+		correction := RotationCorrection{CenterX: &x, CenterY: &y}
+		if err := correction.PackagePattern.UnmarshalText([]byte("^" + regexp.QuoteMeta(fp.Name) + "$")); err != nil {
+			continue
+		}
+
+		corrections = append(corrections, correction)
+	}
+
+	return corrections
+}
+
+// CheckCentroids checks that the center offsets of the rules that match the
+// footprints agree with the centroids of the footprints' geometry (within
+// 0.1mm). Rules without a center offset are checked against the footprint's
+// origin. The disagreements are returned as warnings.
+func CheckCentroids(footprints []footprint.Footprint, tables ...RotationTable) []string {
+	var warnings []string
+
+	seen := make(map[string]bool)
+	for _, fp := range footprints {
+		if seen[fp.Name] {
+			continue
+		}
+		seen[fp.Name] = true
+
+		p := placement.Placement{Package: fp.Name, Val: fp.Value}
+
+		var correction *RotationCorrection
+		for _, table := range tables {
+			var ok bool
+			if correction, ok = table.lookup(p); ok {
+				break
+			}
+		}
+		if correction == nil {
+			continue
+		}
+
+		x, y, source, ok := CentroidOffset(fp)
+		if !ok {
+			continue
+		}
+
+		cx, cy := valueOrZero(correction.CenterX), valueOrZero(correction.CenterY)
+		if math.Hypot(x-cx, y-cy) < centroidTolerance {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf("the rule for %s offsets the center of %s by (%g, %g), but the centroid of its %s is at (%g, %g)",
+			describeRule(*correction), fp.Name, cx, cy, source, x, y))
+	}
+
+	return warnings
+}
+
+// roundOffset rounds a center offset to a micrometre.
+func roundOffset(v float64) float64 {
+	v = math.Round(v*1000) / 1000
+	if v == 0 {
+		// Avoid negative zero.
+		return 0
+	}
+
+	return v
+}
+
+// distinct counts the distinct values of the pads (within padTolerance).
+func distinct(pads []footprint.Pad, value func(footprint.Pad) float64) int {
+	values := make([]float64, 0, len(pads))
+	for _, pad := range pads {
+		values = append(values, value(pad))
+	}
+	sort.Float64s(values)
+
+	count := 0
+	for i, v := range values {
+		if i == 0 || v-values[i-1] >= padTolerance {
+			count++
+		}
+	}
+
+	return count
+}
+
+// hasSinglePadRow reports whether one of the two rows of pads (along the top
+// and bottom if horizontal, otherwise on the left and right) is a single pad.
+func hasSinglePadRow(pads []footprint.Pad, horizontal bool) bool {
+	position := func(pad footprint.Pad) float64 { return pad.X }
+	if horizontal {
+		position = func(pad footprint.Pad) float64 { return pad.Y }
+	}
+
+	first := position(pads[0])
+
+	var count int
+	for _, pad := range pads {
+		if math.Abs(position(pad)-first) < padTolerance {
+			count++
+		}
+	}
+
+	return count == 1 || count == len(pads)-1
+}
+
+// angleDiff returns the difference between two angles (in degrees, [0, 180]).
+func angleDiff(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+
+	return d
+}
+
+// describeDirection describes an angle (counter-clockwise from the positive X
+// axis) as a direction, eg. "upper left".
+func describeDirection(angle float64) string {
+	directions := []string{"right", "upper right", "top", "upper left", "left", "lower left", "bottom", "lower right"}
+
+	i := int(math.Round(clampRotation(angle)/45)) % len(directions)
+	return directions[i]
+}
@@ -0,0 +1,393 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/footprint"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferRotation(t *testing.T) {
+	soic, err := footprint.Load("../kicad/footprint/testdata/SOIC-8_3.9x4.9mm_P1.27mm.kicad_mod")
+	require.NoError(t, err)
+
+	header, err := footprint.Load("../kicad/footprint/testdata/PinHeader_1x03_P2.54mm_Vertical.kicad_mod")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		footprint footprint.Footprint
+		class     string
+		pin1      string
+		rotation  float64
+	}{
+		{
+			name:      "SOIC",
+			footprint: soic[0],
+			class:     jlcpcb.ClassDualRow,
+			pin1:      "upper left",
+			rotation:  -90,
+		},
+		{
+			name:      "Pin Header",
+			footprint: header[0],
+			class:     jlcpcb.ClassInline,
+			pin1:      "top",
+			rotation:  -90,
+		},
+		{
+			name: "Chip Resistor",
+			footprint: footprint.Footprint{Name: "R_0603_1608Metric", Pads: []footprint.Pad{
+				{Number: "1", X: -0.825},
+				{Number: "2", X: 0.825},
+			}},
+			class:    jlcpcb.ClassInline,
+			pin1:     "left",
+			rotation: 0,
+		},
+		{
+			name: "Diode Reversed",
+			footprint: footprint.Footprint{Name: "D_Custom", Pads: []footprint.Pad{
+				{Number: "2", X: -2},
+				{Number: "1", X: 2},
+			}},
+			class:    jlcpcb.ClassInline,
+			pin1:     "right",
+			rotation: 180,
+		},
+		{
+			name:      "SOT-23",
+			footprint: loadFootprint(t, "SOT-23"),
+			class:     jlcpcb.ClassTab,
+			pin1:      "upper left",
+			rotation:  180,
+		},
+		{
+			name:      "SOT-223",
+			footprint: loadFootprint(t, "SOT-223-3_TabPin2"),
+			class:     jlcpcb.ClassTab,
+			pin1:      "upper left",
+			rotation:  180,
+		},
+		{
+			name:      "SOT-23-5",
+			footprint: loadFootprint(t, "SOT-23-5"),
+			class:     jlcpcb.ClassDualRow,
+			pin1:      "upper left",
+			rotation:  -90,
+		},
+		{
+			name:      "QFN With Exposed Pad",
+			footprint: qfn("QFN-16-1EP_3x3mm_P0.5mm_EP1.7x1.7mm"),
+			class:     jlcpcb.ClassQuad,
+			pin1:      "upper left",
+			rotation:  -90,
+		},
+		{
+			name: "Already Zero Orientation",
+			footprint: footprint.Footprint{Name: "SOIC-8_Rotated", Pads: []footprint.Pad{
+				{Number: "1", X: -1.905, Y: 2.475},
+				{Number: "2", X: -0.635, Y: 2.475},
+				{Number: "3", X: 0.635, Y: 2.475},
+				{Number: "4", X: 1.905, Y: 2.475},
+				{Number: "5", X: 1.905, Y: -2.475},
+				{Number: "6", X: 0.635, Y: -2.475},
+				{Number: "7", X: -0.635, Y: -2.475},
+				{Number: "8", X: -1.905, Y: -2.475},
+			}},
+			class:    jlcpcb.ClassDualRow,
+			pin1:     "lower left",
+			rotation: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposal, err := jlcpcb.InferRotation(tt.footprint)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.footprint.Name, proposal.Footprint)
+			assert.Equal(t, tt.class, proposal.Class)
+			assert.Equal(t, tt.pin1, proposal.Pin1)
+			assert.Equal(t, tt.rotation, proposal.Correction.Rotation)
+			assert.True(t, proposal.Correction.PackagePattern.MatchString(tt.footprint.Name))
+			assert.False(t, proposal.Correction.PackagePattern.MatchString(tt.footprint.Name+"_Extra"))
+		})
+	}
+}
+
+func TestInferRotationCorpus(t *testing.T) {
+	table, ok := jlcpcb.RotationTableFor("kicad")
+	require.True(t, ok)
+
+	corpus, ok := jlcpcb.CorpusFor("kicad")
+	require.True(t, ok)
+
+	var checked int
+	for _, entry := range corpus {
+		if _, err := os.Stat(footprintPath(entry.Package)); err != nil {
+			continue
+		}
+
+		// Only rules for the whole package can be inferred, value specific rules
+		// correct parts that differ from the footprint.
+		correction, ok := jlcpcb.FindRotationCorrection(placement.Placement{Package: entry.Package, Val: entry.Value}, table)
+		if !ok || correction.ValuePattern.Regexp != nil {
+			continue
+		}
+
+		t.Run(entry.Package, func(t *testing.T) {
+			proposal, err := jlcpcb.InferRotation(loadFootprint(t, entry.Package))
+			require.NoError(t, err)
+
+			assert.Equal(t, correction.Rotation, proposal.Correction.Rotation)
+		})
+		checked++
+	}
+
+	assert.GreaterOrEqual(t, checked, 7)
+}
+
+func TestInferRotationCentroid(t *testing.T) {
+	header, err := footprint.Load("../kicad/footprint/testdata/PinHeader_1x03_P2.54mm_Vertical.kicad_mod")
+	require.NoError(t, err)
+
+	proposal, err := jlcpcb.InferRotation(header[0])
+	require.NoError(t, err)
+
+	// The origin is at pin 1, so the center is offset to the middle pin.
+	assert.Equal(t, "courtyard", proposal.Centroid)
+	require.NotNil(t, proposal.Correction.CenterX)
+	assert.Equal(t, 0.0, *proposal.Correction.CenterX)
+	require.NotNil(t, proposal.Correction.CenterY)
+	assert.Equal(t, -2.525, *proposal.Correction.CenterY)
+
+	soic, err := footprint.Load("../kicad/footprint/testdata/SOIC-8_3.9x4.9mm_P1.27mm.kicad_mod")
+	require.NoError(t, err)
+
+	proposal, err = jlcpcb.InferRotation(soic[0])
+	require.NoError(t, err)
+
+	// The origin is already the centroid.
+	assert.Empty(t, proposal.Centroid)
+	assert.Nil(t, proposal.Correction.CenterX)
+	assert.Nil(t, proposal.Correction.CenterY)
+}
+
+func TestCentroidOffset(t *testing.T) {
+	fp := footprint.Footprint{Name: "JST_PH_B2B-PH-K_1x02_P2.00mm_Vertical", Pads: []footprint.Pad{
+		{Number: "1", X: 0, Y: 0, Width: 1.2, Height: 1.75},
+		{Number: "2", X: 2, Y: 0, Width: 1.2, Height: 1.75},
+	}}
+
+	x, y, source, ok := jlcpcb.CentroidOffset(fp)
+	require.True(t, ok)
+	assert.Equal(t, "pads", source)
+	assert.Equal(t, 1.0, x)
+	assert.Equal(t, 0.0, y)
+
+	fp.Courtyard = &footprint.Rect{MinX: -2.45, MinY: -2.2, MaxX: 4.45, MaxY: 3.3}
+
+	x, y, source, ok = jlcpcb.CentroidOffset(fp)
+	require.True(t, ok)
+	assert.Equal(t, "courtyard", source)
+	assert.Equal(t, 1.0, x)
+	assert.Equal(t, -0.55, y)
+}
+
+func TestCheckCentroids(t *testing.T) {
+	footprints, err := footprint.Load("../kicad/footprint/testdata/board.kicad_pcb")
+	require.NoError(t, err)
+
+	// The built-in rules agree with the geometry (within tolerance).
+	table, ok := jlcpcb.RotationTableFor("kicad")
+	require.True(t, ok)
+	assert.Empty(t, jlcpcb.CheckCentroids(footprints, table))
+
+	overrides, err := jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^PinHeader_1x03_P2.54mm_Vertical$","",-90,,2.5
+"^R_0603_1608Metric$","",0,,
+`))
+	require.NoError(t, err)
+
+	warnings := jlcpcb.CheckCentroids(footprints, overrides, table)
+	assert.Equal(t, []string{
+		`the rule for "^PinHeader_1x03_P2.54mm_Vertical$" offsets the center of PinHeader_1x03_P2.54mm_Vertical by (0, 2.5), but the centroid of its courtyard is at (0, -2.525)`,
+	}, warnings)
+
+	// Rules without an offset are checked against the origin.
+	overrides, err = jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^PinHeader_1x03_P2.54mm_Vertical$","",-90,,
+`))
+	require.NoError(t, err)
+
+	warnings = jlcpcb.CheckCentroids(footprints, overrides)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "by (0, 0)")
+}
+
+func TestCentroidCorrections(t *testing.T) {
+	footprints, err := footprint.Load("../kicad/footprint/testdata/board.kicad_pcb")
+	require.NoError(t, err)
+
+	// The origins of the other footprints are their centroids.
+	table, ok := jlcpcb.RotationTableFor("kicad")
+	require.True(t, ok)
+
+	corrections := jlcpcb.CentroidCorrections(footprints, table)
+	require.Len(t, corrections, 1)
+	assert.Equal(t, "^PinHeader_1x03_P2\\.54mm_Vertical$", corrections[0].PackagePattern.String())
+	assert.Equal(t, 0.0, corrections[0].Rotation)
+	require.NotNil(t, corrections[0].CenterX)
+	require.NotNil(t, corrections[0].CenterY)
+	assert.Equal(t, 0.0, *corrections[0].CenterX)
+	assert.Equal(t, -2.525, *corrections[0].CenterY)
+
+	// Footprints with a rule are left alone.
+	overrides, err := jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^PinHeader_1x03_P2.54mm_Vertical$","",-90,,
+`))
+	require.NoError(t, err)
+	assert.Empty(t, jlcpcb.CentroidCorrections(footprints, overrides, table))
+}
+
+func TestInferRotationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		footprint footprint.Footprint
+	}{
+		{
+			name: "No Pin 1",
+			footprint: footprint.Footprint{Pads: []footprint.Pad{
+				{Number: "A", X: -1},
+				{Number: "K", X: 1},
+			}},
+		},
+		{
+			name:      "Single Pad",
+			footprint: footprint.Footprint{Pads: []footprint.Pad{{Number: "1"}}},
+		},
+		{
+			// Pin 1 on the wrong row means the footprint is mirrored.
+			name: "Mirrored",
+			footprint: footprint.Footprint{Pads: []footprint.Pad{
+				{Number: "1", X: -2.475, Y: 1.905},
+				{Number: "2", X: -2.475, Y: 0.635},
+				{Number: "3", X: -2.475, Y: -0.635},
+				{Number: "4", X: 2.475, Y: -0.635},
+				{Number: "5", X: 2.475, Y: 0.635},
+				{Number: "6", X: 2.475, Y: 1.905},
+			}},
+		},
+		{
+			name: "Pin 1 In The Center",
+			footprint: footprint.Footprint{Pads: []footprint.Pad{
+				{Number: "2", X: -2.54},
+				{Number: "1", X: 0},
+				{Number: "3", X: 2.54},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jlcpcb.InferRotation(tt.footprint)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProposeRotations(t *testing.T) {
+	footprints, err := footprint.Load("../kicad/footprint/testdata/board.kicad_pcb")
+	require.NoError(t, err)
+
+	footprints = append(footprints,
+		footprint.Footprint{Name: "MountingHole_3.2mm_M3", Pads: []footprint.Pad{{X: 0, Y: 0}}},
+		qfn("SOIC-8_3.9x4.9mm_P1.27mm"))
+
+	table, err := jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^SOIC-8_3.9x4.9mm_P1.27mm$","",-90,,
+"^PinHeader_1x03_P2.54mm_Vertical$","",-90,,-2.54
+`))
+	require.NoError(t, err)
+
+	proposals, warnings := jlcpcb.ProposeRotations(footprints, table)
+
+	// The SOIC and pin header have rules, duplicates are only considered once,
+	// and the resistor doesn't need a correction.
+	assert.Empty(t, proposals)
+
+	proposals, _ = jlcpcb.ProposeRotations([]footprint.Footprint{loadFootprint(t, "SOT-23")}, table)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, "SOT-23", proposals[0].Footprint)
+	assert.Equal(t, 180.0, proposals[0].Correction.Rotation)
+
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "MountingHole_3.2mm_M3")
+}
+
+// qfn returns a 16 pin QFN footprint (with pin 1 at the upper left, and an
+// exposed pad).
+func qfn(name string) footprint.Footprint {
+	fp := footprint.Footprint{Name: name}
+
+	number := 1
+	add := func(x, y float64) {
+		fp.Pads = append(fp.Pads, footprint.Pad{Number: strconv.Itoa(number), X: x, Y: y})
+		number++
+	}
+
+	for i := range 4 {
+		add(-1.45, -0.75+0.5*float64(i))
+	}
+	for i := range 4 {
+		add(-0.75+0.5*float64(i), 1.45)
+	}
+	for i := range 4 {
+		add(1.45, 0.75-0.5*float64(i))
+	}
+	for i := range 4 {
+		add(0.75-0.5*float64(i), -1.45)
+	}
+
+	fp.Pads = append(fp.Pads, footprint.Pad{Number: "17", Width: 1.7, Height: 1.7})
+
+	return fp
+}
+
+func footprintPath(name string) string {
+	return filepath.Join("testdata/footprints", name+".kicad_mod")
+}
+
+// loadFootprint loads a footprint from testdata/footprints.
+func loadFootprint(t *testing.T, name string) footprint.Footprint {
+	footprints, err := footprint.Load(footprintPath(name))
+	require.NoError(t, err)
+	require.Len(t, footprints, 1)
+
+	return footprints[0]
+}
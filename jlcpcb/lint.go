@@ -0,0 +1,442 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+)
+
+// LintIssue is a problem found in a rotation correction table.
+type LintIssue struct {
+	// Line is the line of the rule in the table (0 for corpus failures).
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (i LintIssue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// CorpusEntry is a known footprint, used to check a rotation correction table.
+type CorpusEntry struct {
+	Package string `csv:"Package" json:"package" yaml:"package"`
+	Value   string `csv:"Value" json:"value,omitempty" yaml:"value,omitempty"`
+	// Rotation is the expected rotation correction, 0 if no rule should match
+	// (nil if there's no expectation).
+	Rotation *float64 `csv:"Rotation" json:"rotation,omitempty" yaml:"rotation,omitempty"`
+}
+
+// corpusFS holds the built-in corpora, named after the input format they apply
+// to (eg. "kicad_corpus.csv").
+//
+//go:embed *_corpus.csv
+var corpusFS embed.FS
+
+// LoadCorpus loads a corpus of known footprints from CSV (lines starting with
+// '#' are comments).
+func LoadCorpus(r io.Reader) ([]CorpusEntry, error) {
+	corpus, err := csvx.Unmarshal[CorpusEntry](r, csvx.WithComment('#'))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse corpus: %w", err)
+	}
+
+	return corpus, nil
+}
+
+// CorpusFor returns the built-in corpus of known footprints for an input format.
+func CorpusFor(format string) ([]CorpusEntry, bool) {
+	data, err := corpusFS.ReadFile(format + "_corpus.csv")
+	if err != nil {
+		return nil, false
+	}
+
+	corpus, err := LoadCorpus(bytes.NewReader(data))
+	if err != nil {
+		panic(fmt.Errorf("%s_corpus.csv: %w", format, err))
+	}
+
+	return corpus, true
+}
+
+// BuiltinRotationTables returns the input formats that have a built-in rotation
+// correction table.
+func BuiltinRotationTables() []string {
+	names, err := fs.Glob(rotationsFS, "*_rotations.csv")
+	if err != nil {
+		panic(err)
+	}
+
+	formats := make([]string, 0, len(names))
+	for _, name := range names {
+		formats = append(formats, strings.TrimSuffix(name, "_rotations.csv"))
+	}
+
+	return formats
+}
+
+// LintBuiltinRotationTable lints the built-in rotation correction table of an
+// input format, against its built-in corpus (and any extra corpus entries).
+func LintBuiltinRotationTable(format string, extra ...CorpusEntry) ([]LintIssue, error) {
+	data, err := rotationsFS.ReadFile(format + "_rotations.csv")
+	if err != nil {
+		return nil, fmt.Errorf("no built-in rotation corrections for format: %s", format)
+	}
+
+	corpus, _ := CorpusFor(format)
+
+	return LintRotationTable(bytes.NewReader(data), append(corpus, extra...))
+}
+
+// lintRule is a rotation correction and the line it was read from.
+type lintRule struct {
+	line       int
+	correction RotationCorrection
+}
+
+// LintRotationTable checks a rotation correction table (as CSV) for rules that
+// fail to parse, duplicate rules, rules that can never be selected (because a
+// more specific rule always wins) and rules of the same specificity that would
+// apply conflicting corrections to the same footprint. Rules are exercised with
+// an example footprint generated from each pattern and the corpus, any corpus
+// entries with an expected rotation are also checked.
+func LintRotationTable(r io.Reader, corpus []CorpusEntry) ([]LintIssue, error) {
+	dec := csvx.NewDecoder[RotationCorrection](r, csvx.WithComment('#'))
+
+	header, err := dec.Header()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rotation corrections: %w", err)
+	}
+
+	var issues []LintIssue
+	for _, column := range []string{"Package pattern", "Rotation"} {
+		if !csvx.HasColumns(header, column) {
+			issues = append(issues, LintIssue{Line: 1, Message: fmt.Sprintf("missing column %q", column)})
+		}
+	}
+	if len(issues) > 0 {
+		return issues, nil
+	}
+
+	var rules []lintRule
+	for {
+		var correction RotationCorrection
+		err := dec.Decode(&correction)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			issues = append(issues, rowIssue(dec.Line(), err))
+			continue
+		}
+
+		if correction.PackagePattern.Regexp == nil {
+			issues = append(issues, LintIssue{Line: dec.Line(), Message: "rule has no package pattern (it never matches)"})
+			continue
+		}
+
+		rules = append(rules, lintRule{line: dec.Line(), correction: correction})
+	}
+
+	// reported holds the pairs of rules that already have an issue.
+	reported := make(map[[2]int]bool)
+
+	// Duplicate rules.
+	seen := make(map[string]lintRule)
+	for _, rule := range rules {
+		key := rule.correction.PackagePattern.String() + "\x00" + rule.correction.ValuePattern.String()
+
+		first, ok := seen[key]
+		if !ok {
+			seen[key] = rule
+			continue
+		}
+
+		message := fmt.Sprintf("duplicate of the rule on line %d", first.line)
+		if !sameCorrection(first.correction, rule.correction) {
+			message = fmt.Sprintf("conflicts with the rule on line %d (same patterns, different correction)", first.line)
+		}
+		issues = append(issues, LintIssue{Line: rule.line, Message: message})
+		reported[[2]int{first.line, rule.line}] = true
+	}
+
+	// Exercise the rules with the corpus and an example of each pattern.
+	probes := make([]placement.Placement, 0, len(corpus)+len(rules))
+	for _, entry := range corpus {
+		probes = append(probes, placement.Placement{Package: entry.Package, Val: entry.Value})
+	}
+	for _, rule := range rules {
+		if probe, ok := exampleProbe(rule.correction); ok {
+			probes = append(probes, probe)
+		}
+	}
+
+	matched := make(map[int]bool)
+	shadowedBy := make(map[int]lintShadow)
+	won := make(map[int]bool)
+	for _, probe := range probes {
+		matches := matchingRules(rules, probe)
+		if len(matches) == 0 {
+			continue
+		}
+
+		winner := matches[0]
+		won[winner.line] = true
+
+		for _, rule := range matches[1:] {
+			matched[rule.line] = true
+			if _, ok := shadowedBy[rule.line]; !ok {
+				shadowedBy[rule.line] = lintShadow{line: winner.line, probe: probe}
+			}
+
+			if rule.correction.specificity() != winner.correction.specificity() ||
+				sameCorrection(rule.correction, winner.correction) {
+				continue
+			}
+
+			pair := [2]int{winner.line, rule.line}
+			if reported[pair] {
+				continue
+			}
+			reported[pair] = true
+
+			issues = append(issues, LintIssue{
+				Line: rule.line,
+				Message: fmt.Sprintf("has the same specificity as the rule on line %d but a different correction (eg. for %s)",
+					winner.line, describeProbe(probe)),
+			})
+		}
+	}
+
+	for _, rule := range rules {
+		shadow, ok := shadowedBy[rule.line]
+		if !matched[rule.line] || won[rule.line] || !ok || reported[[2]int{shadow.line, rule.line}] {
+			continue
+		}
+
+		issues = append(issues, LintIssue{
+			Line:    rule.line,
+			Message: fmt.Sprintf("shadowed by the rule on line %d (eg. for %s)", shadow.line, describeProbe(shadow.probe)),
+		})
+	}
+
+	// Check the expected rotations of the corpus.
+	table := make(RotationTable, 0, len(rules))
+	for _, rule := range rules {
+		table = append(table, rule.correction)
+	}
+
+	for _, entry := range corpus {
+		if entry.Rotation == nil {
+			continue
+		}
+
+		var rotation float64
+		if correction, ok := table.lookup(placement.Placement{Package: entry.Package, Val: entry.Value}); ok {
+			rotation = correction.Rotation
+		}
+
+		if clampRotation(rotation) != clampRotation(*entry.Rotation) {
+			issues = append(issues, LintIssue{
+				Message: fmt.Sprintf("corpus: %s expected a rotation correction of %g but got %g",
+					describeProbe(placement.Placement{Package: entry.Package, Val: entry.Value}), *entry.Rotation, rotation),
+			})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line == 0 || issues[j].Line == 0 {
+			return issues[i].Line != 0 && issues[j].Line == 0
+		}
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues, nil
+}
+
+// lintShadow records the rule that won over a shadowed rule.
+type lintShadow struct {
+	line  int
+	probe placement.Placement
+}
+
+// rowIssue converts a row decoding error into an issue.
+func rowIssue(line int, err error) LintIssue {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return LintIssue{Line: parseErr.Line, Message: parseErr.Err.Error()}
+	}
+
+	// Remove the line number added by the decoder.
+	if inner := errors.Unwrap(err); inner != nil {
+		err = inner
+	}
+
+	return LintIssue{Line: line, Message: err.Error()}
+}
+
+// matchingRules returns the rules that match a placement, most specific first
+// (in the same order as RotationTable.lookup).
+func matchingRules(rules []lintRule, p placement.Placement) []lintRule {
+	var matches []lintRule
+	for _, rule := range rules {
+		c := rule.correction
+		if !c.PackagePattern.MatchString(p.Package) {
+			continue
+		}
+		if c.ValuePattern.Regexp != nil && !c.ValuePattern.MatchString(p.Val) {
+			continue
+		}
+
+		matches = append(matches, rule)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].correction.specificity() > matches[j].correction.specificity()
+	})
+
+	return matches
+}
+
+// sameCorrection reports whether two rules apply the same correction.
+func sameCorrection(a, b RotationCorrection) bool {
+	return clampRotation(a.Rotation) == clampRotation(b.Rotation) &&
+		valueOrZero(a.CenterX) == valueOrZero(b.CenterX) &&
+		valueOrZero(a.CenterY) == valueOrZero(b.CenterY)
+}
+
+func describeProbe(p placement.Placement) string {
+	if p.Val == "" {
+		return fmt.Sprintf("%q", p.Package)
+	}
+
+	return fmt.Sprintf("%q (value %q)", p.Package, p.Val)
+}
+
+// exampleProbe generates a footprint that a rule matches.
+func exampleProbe(c RotationCorrection) (placement.Placement, bool) {
+	pkg, ok := example(c.PackagePattern.String())
+	if !ok || !c.PackagePattern.MatchString(pkg) {
+		return placement.Placement{}, false
+	}
+
+	var val string
+	if c.ValuePattern.Regexp != nil {
+		val, ok = example(c.ValuePattern.String())
+		if !ok || !c.ValuePattern.MatchString(val) {
+			return placement.Placement{}, false
+		}
+	}
+
+	return placement.Placement{Package: pkg, Val: val}, true
+}
+
+// example generates a (short) string matched by a regular expression.
+func example(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	var sb strings.Builder
+	if !writeExample(&sb, re.Simplify()) {
+		return "", false
+	}
+
+	return sb.String(), true
+}
+
+func writeExample(sb *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		r, ok := exampleRune(re.Rune)
+		if !ok {
+			return false
+		}
+		sb.WriteRune(r)
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteRune('x')
+	case syntax.OpCapture:
+		return writeExample(sb, re.Sub[0])
+	case syntax.OpPlus:
+		return writeExample(sb, re.Sub[0])
+	case syntax.OpRepeat:
+		for i := 0; i < re.Min; i++ {
+			if !writeExample(sb, re.Sub[0]) {
+				return false
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeExample(sb, sub) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		return writeExample(sb, re.Sub[0])
+	case syntax.OpNoMatch:
+		return false
+	default:
+		// Empty matches, anchors, and optional or repeated (zero or more) parts.
+	}
+
+	return true
+}
+
+// exampleRune picks a readable rune from a character class (given as ranges).
+func exampleRune(ranges []rune) (rune, bool) {
+	if len(ranges) < 2 {
+		return 0, false
+	}
+
+	for _, r := range "0aA_-." {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= r && r <= ranges[i+1] {
+				return r, true
+			}
+		}
+	}
+
+	// Otherwise, avoid control characters and spaces if possible.
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i+1] >= '!' {
+			return max(ranges[i], '!'), true
+		}
+	}
+
+	return ranges[0], true
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRotationTable(t *testing.T) {
+	f, err := os.Open("testdata/lint_rotations.csv")
+	require.NoError(t, err)
+	defer f.Close()
+
+	corpus, err := jlcpcb.LoadCorpus(strings.NewReader(`"Package","Value","Rotation"
+"LQFP-48_7x7mm_P0.5mm","",
+"SOT-23-5","",90
+`))
+	require.NoError(t, err)
+
+	issues, err := jlcpcb.LintRotationTable(f, corpus)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+
+	assert.Equal(t, []string{
+		"line 3: failed to unmarshal field PackagePattern: error parsing regexp: missing closing ): `^SOT-23-(5$`",
+		"line 4: duplicate of the rule on line 2",
+		"line 5: conflicts with the rule on line 2 (same patterns, different correction)",
+		"line 8: shadowed by the rule on line 9 (eg. for \"SOIC-8\")",
+		"line 11: has the same specificity as the rule on line 10 but a different correction (eg. for \"LQFP-48_7x7mm_P0.5mm\")",
+		"line 12: rule has no package pattern (it never matches)",
+		"line 13: invalid float for field Rotation: abc",
+		"corpus: \"SOT-23-5\" expected a rotation correction of 90 but got -90",
+	}, lines)
+}
+
+func TestLintRotationTableMissingColumns(t *testing.T) {
+	issues, err := jlcpcb.LintRotationTable(strings.NewReader(`"Footprint pattern","Rotation","Center X","Center Y"
+"^SOT-23-5$",-90,,
+`), nil)
+	require.NoError(t, err)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Line)
+	assert.Contains(t, issues[0].Message, "Package pattern")
+}
+
+func TestLintBuiltinRotationTables(t *testing.T) {
+	formats := jlcpcb.BuiltinRotationTables()
+	assert.Contains(t, formats, "kicad")
+
+	for _, format := range formats {
+		_, ok := jlcpcb.CorpusFor(format)
+		assert.True(t, ok, format)
+
+		issues, err := jlcpcb.LintBuiltinRotationTable(format)
+		require.NoError(t, err)
+		assert.Empty(t, issues, format)
+	}
+}
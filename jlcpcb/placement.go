@@ -19,10 +19,15 @@ package jlcpcb
 
 import (
 	"bytes"
-	_ "embed"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"math"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/dpeckett/jlcfabtool/csvx"
 	"github.com/dpeckett/jlcfabtool/kicad/placement"
@@ -30,11 +35,12 @@ import (
 
 // RotationCorrection defines how to adjust placement for a component.
 type RotationCorrection struct {
-	PackagePattern UnmarshallableRegexp `csv:"Package pattern"`
-	ValuePattern   UnmarshallableRegexp `csv:"Value pattern"`
-	Rotation       float64              `csv:"Rotation"`
-	CenterX        float64              `csv:"Center X"`
-	CenterY        float64              `csv:"Center Y"`
+	PackagePattern UnmarshallableRegexp `csv:"Package pattern" json:"package_pattern" yaml:"package_pattern" toml:"package_pattern"`
+	ValuePattern   UnmarshallableRegexp `csv:"Value pattern" json:"value_pattern,omitempty" yaml:"value_pattern,omitempty" toml:"value_pattern,omitempty"`
+	Rotation       float64              `csv:"Rotation" json:"rotation" yaml:"rotation" toml:"rotation"`
+	// CenterX and CenterY offset the component's center (nil if not specified).
+	CenterX *float64 `csv:"Center X" json:"center_x,omitempty" yaml:"center_x,omitempty" toml:"center_x,omitempty"`
+	CenterY *float64 `csv:"Center Y" json:"center_y,omitempty" yaml:"center_y,omitempty" toml:"center_y,omitempty"`
 }
 
 // specificity determines how specific a rule is (used for sorting).
@@ -49,29 +55,111 @@ func (c RotationCorrection) specificity() int {
 	return score
 }
 
-//go:embed kicad_rotations.csv
-var rotationDBData []byte
+// RotationTable is a set of rotation corrections.
+type RotationTable []RotationCorrection
 
-var rotationDB []RotationCorrection
+// rotationsFS holds the built-in rotation correction tables, named after the
+// input format they apply to (eg. "kicad_rotations.csv").
+//
+//go:embed *_rotations.csv
+var rotationsFS embed.FS
+
+// rotationDB is the built-in table for KiCad footprints.
+var rotationDB RotationTable
+
+var (
+	rotationTablesMu sync.RWMutex
+	rotationTables   = map[string]RotationTable{}
+)
 
 func init() {
-	var err error
-	rotationDB, err = csvx.Unmarshal[RotationCorrection](bytes.NewReader(rotationDBData))
+	names, err := fs.Glob(rotationsFS, "*_rotations.csv")
 	if err != nil {
 		panic(err)
 	}
+
+	for _, name := range names {
+		data, err := rotationsFS.ReadFile(name)
+		if err != nil {
+			panic(err)
+		}
+
+		table, err := LoadRotationTable(bytes.NewReader(data))
+		if err != nil {
+			panic(fmt.Errorf("%s: %w", name, err))
+		}
+
+		RegisterRotationTable(strings.TrimSuffix(name, "_rotations.csv"), table)
+	}
+
+	rotationDB, _ = RotationTableFor("kicad")
+}
+
+// RegisterRotationTable registers the built-in rotation correction table for an
+// input format (see placement.RegisterReader). Package names differ between EDA
+// tools, so each format needs its own set of corrections.
+func RegisterRotationTable(format string, table RotationTable) {
+	rotationTablesMu.Lock()
+	defer rotationTablesMu.Unlock()
+
+	rotationTables[format] = table
+}
+
+// RotationTableFor returns the built-in rotation correction table for an input
+// format (eg. "kicad").
+func RotationTableFor(format string) (RotationTable, bool) {
+	rotationTablesMu.RLock()
+	defer rotationTablesMu.RUnlock()
+
+	table, ok := rotationTables[format]
+	return table, ok
+}
+
+// LoadRotationTable loads a rotation correction table from CSV (lines starting
+// with '#' are comments).
+func LoadRotationTable(r io.Reader) (RotationTable, error) {
+	corrections, err := csvx.Unmarshal[RotationCorrection](r, csvx.WithComment('#'))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rotation corrections: %w", err)
+	}
+
+	return corrections, nil
 }
 
-// ApplyRotationCorrection applies a rotation correction based on package and optional value.
+// ApplyRotationCorrection applies a rotation correction based on package and
+// optional value, using the built-in KiCad table.
 func ApplyRotationCorrection(p placement.Placement) *placement.Placement {
+	correction, ok := FindRotationCorrection(p, rotationDB)
+	if !ok {
+		return &p
+	}
+
+	return correction.Apply(p)
+}
+
+// FindRotationCorrection finds the most specific rotation correction for a
+// placement (if any). The tables are consulted in order, so overrides should
+// come before the built-in table.
+func FindRotationCorrection(p placement.Placement, tables ...RotationTable) (*RotationCorrection, bool) {
 	slog.Info(
 		"Checking for rotation correction",
 		slog.String("package", p.Package),
 		slog.String("value", p.Val),
 	)
 
+	for _, table := range tables {
+		if correction, ok := table.lookup(p); ok {
+			return correction, true
+		}
+	}
+
+	return nil, false
+}
+
+// lookup finds the most specific rotation correction for a placement.
+func (t RotationTable) lookup(p placement.Placement) (*RotationCorrection, bool) {
 	var matches []RotationCorrection
-	for _, correction := range rotationDB {
+	for _, correction := range t {
 		// Package must match
 		if correction.PackagePattern.Regexp == nil {
 			continue
@@ -90,28 +178,31 @@ func ApplyRotationCorrection(p placement.Placement) *placement.Placement {
 	}
 
 	if len(matches) == 0 {
-		return &p
+		return nil, false
 	}
 
-	slog.Info("Applying rotation correction", slog.String("package", p.Package))
-
-	// Pick most specific match
-	sort.Slice(matches, func(i, j int) bool {
+	// Pick most specific match (the first rule wins if several are equally specific)
+	sort.SliceStable(matches, func(i, j int) bool {
 		return matches[i].specificity() > matches[j].specificity()
 	})
 
-	correction := matches[0]
+	return &matches[0], true
+}
+
+// Apply applies the rotation correction to a placement.
+func (c RotationCorrection) Apply(p placement.Placement) *placement.Placement {
+	slog.Info("Applying rotation correction", slog.String("package", p.Package))
 
 	// Apply center offset + rotation
 	rotatedX, rotatedY := rotatePoint(
-		p.PosX+correction.CenterX,
-		p.PosY+correction.CenterY,
+		p.PosX+valueOrZero(c.CenterX),
+		p.PosY+valueOrZero(c.CenterY),
 		p.PosX,
 		p.PosY,
 		p.Rot,
 	)
 
-	finalRotation := clampRotation(p.Rot + correction.Rotation)
+	finalRotation := clampRotation(p.Rot + c.Rotation)
 
 	slog.Debug(
 		"Rotation correction applied",
@@ -134,6 +225,14 @@ func ApplyRotationCorrection(p placement.Placement) *placement.Placement {
 	}
 }
 
+// valueOrZero dereferences an optional value.
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
 // rotatePoint rotates a point (x, y) around origin (x0, y0) by theta degrees.
 func rotatePoint(x, y, x0, y0, theta float64) (float64, float64) {
 	thetaRad := theta * (math.Pi / 180.0)
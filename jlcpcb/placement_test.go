@@ -18,11 +18,13 @@
 package jlcpcb_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/dpeckett/jlcfabtool/jlcpcb"
 	"github.com/dpeckett/jlcfabtool/kicad/placement"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestApplyRotationCorrection(t *testing.T) {
@@ -102,3 +104,87 @@ func TestApplyRotationCorrectionNoMatch(t *testing.T) {
 	assert.InDelta(t, 270.0, p.Rot, 0.000001)
 	assert.Equal(t, "bottom", p.Side)
 }
+
+func TestFindRotationCorrectionOverrides(t *testing.T) {
+	overrides, err := jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^SOT-23-5$","",180,1,
+`))
+	require.NoError(t, err)
+
+	p := placement.Placement{
+		Ref:     "U2",
+		Val:     "AP2112K-3.3",
+		Package: "SOT-23-5",
+		PosX:    10.0,
+		PosY:    20.0,
+		Rot:     90.0,
+		Side:    "top",
+	}
+
+	kicadRotations, ok := jlcpcb.RotationTableFor("kicad")
+	require.True(t, ok)
+
+	correction, ok := jlcpcb.FindRotationCorrection(p, overrides, kicadRotations)
+	require.True(t, ok)
+	assert.Equal(t, 180.0, correction.Rotation)
+
+	corrected := correction.Apply(p)
+	assert.InDelta(t, 10.0, corrected.PosX, 0.000001)
+	assert.InDelta(t, 21.0, corrected.PosY, 0.000001)
+	assert.InDelta(t, 270.0, corrected.Rot, 0.000001)
+
+	// Without overrides, the built-in table is used.
+	correction, ok = jlcpcb.FindRotationCorrection(p, nil, kicadRotations)
+	require.True(t, ok)
+	assert.Equal(t, -90.0, correction.Rotation)
+}
+
+func TestRotationTableFor(t *testing.T) {
+	eagleRotations, ok := jlcpcb.RotationTableFor("eagle")
+	require.True(t, ok)
+
+	p := placement.Placement{
+		Ref:     "U1",
+		Val:     "AP2112K-3.3",
+		Package: "SOT23-5",
+		Rot:     90.0,
+		Side:    "top",
+	}
+
+	correction, ok := jlcpcb.FindRotationCorrection(p, eagleRotations)
+	require.True(t, ok)
+	assert.Equal(t, -90.0, correction.Rotation)
+
+	// KiCad's package names don't match Eagle's.
+	kicadRotations, ok := jlcpcb.RotationTableFor("kicad")
+	require.True(t, ok)
+
+	_, ok = jlcpcb.FindRotationCorrection(p, kicadRotations)
+	assert.False(t, ok)
+
+	for _, format := range []string{"altium", "horizon", "librepcb"} {
+		_, ok = jlcpcb.RotationTableFor(format)
+		assert.True(t, ok, format)
+	}
+
+	_, ok = jlcpcb.RotationTableFor("unknown")
+	assert.False(t, ok)
+}
+
+func TestLoadRotationTableOptionalColumns(t *testing.T) {
+	table, err := jlcpcb.LoadRotationTable(strings.NewReader(`"Package pattern","Value pattern","Rotation","Center X","Center Y"
+"^SOT-23$","",180,,
+"^QFN-16$","",90,0.5,0
+`))
+	require.NoError(t, err)
+	require.Len(t, table, 2)
+
+	assert.Nil(t, table[0].ValuePattern.Regexp)
+	assert.Nil(t, table[0].CenterX)
+	assert.Nil(t, table[0].CenterY)
+
+	require.NotNil(t, table[1].CenterX)
+	assert.Equal(t, 0.5, *table[1].CenterX)
+	require.NotNil(t, table[1].CenterY)
+	assert.Equal(t, 0.0, *table[1].CenterY)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+)
+
+// Price is the unit price of an LCSC part.
+type Price struct {
+	LCSC      string  `csv:"LCSC Part Number" json:"lcsc" yaml:"lcsc"`
+	UnitPrice float64 `csv:"Unit Price" json:"unit_price" yaml:"unit_price"`
+}
+
+// PriceList maps (upper case) LCSC part numbers onto their unit price.
+type PriceList map[string]float64
+
+// LoadPriceList loads a price list from CSV (with "LCSC Part Number" and "Unit
+// Price" columns).
+func LoadPriceList(r io.Reader) (PriceList, error) {
+	prices, err := csvx.Unmarshal[Price](r)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse price list: %w", err)
+	}
+
+	list := make(PriceList, len(prices))
+	for _, price := range prices {
+		list[strings.ToUpper(price.LCSC)] = price.UnitPrice
+	}
+
+	return list, nil
+}
+
+// CostLine is the cost of a single BOM entry.
+type CostLine struct {
+	LCSC       string  `json:"lcsc" yaml:"lcsc"`
+	Designator string  `json:"designator" yaml:"designator"`
+	Qty        int     `json:"qty" yaml:"qty"`
+	UnitPrice  float64 `json:"unit_price" yaml:"unit_price"`
+	Total      float64 `json:"total" yaml:"total"`
+	// Priced is false if the part was not in the price list.
+	Priced bool `json:"priced" yaml:"priced"`
+}
+
+// EstimateCost prices the BOM entries (for a single board) using a price list.
+// It returns the cost of each entry and the total cost of the priced entries.
+// The quantity of an entry is the number of designators if the BOM has no
+// quantity.
+func EstimateCost(entries []BOMEntry, prices PriceList) ([]CostLine, float64) {
+	var total float64
+
+	lines := make([]CostLine, 0, len(entries))
+	for _, entry := range entries {
+		line := CostLine{
+			LCSC:       entry.LCSC,
+			Designator: entry.Designator,
+			Qty:        entry.Source.Qty,
+		}
+		if line.Qty == 0 {
+			line.Qty = len(entry.Source.Reference)
+		}
+
+		if entry.LCSC != "" {
+			line.UnitPrice, line.Priced = prices[strings.ToUpper(entry.LCSC)]
+		}
+
+		if line.Priced {
+			line.Total = line.UnitPrice * float64(line.Qty)
+			total += line.Total
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, total
+}
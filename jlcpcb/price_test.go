@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package jlcpcb_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	prices, err := jlcpcb.LoadPriceList(strings.NewReader(`"LCSC Part Number","Unit Price"
+"C14663",0.0021
+"c25804",0.0005
+`))
+	require.NoError(t, err)
+
+	lines, total := jlcpcb.EstimateCost([]jlcpcb.BOMEntry{
+		{Designator: "C1,C2", LCSC: "C14663", Source: bom.Entry{Qty: 2}},
+		{Designator: "R1", LCSC: "C25804", Source: bom.Entry{Qty: 1}},
+		{Designator: "U1", LCSC: "C51118", Source: bom.Entry{Qty: 1}},
+	}, prices)
+	require.Len(t, lines, 3)
+
+	assert.True(t, lines[0].Priced)
+	assert.InDelta(t, 0.0042, lines[0].Total, 0.000001)
+	assert.True(t, lines[1].Priced)
+	assert.False(t, lines[2].Priced)
+	assert.InDelta(t, 0.0047, total, 0.000001)
+}
+
+func TestEstimateCostWithoutQty(t *testing.T) {
+	prices, err := jlcpcb.LoadPriceList(strings.NewReader(`"LCSC Part Number","Unit Price"
+"C14663",0.0021
+`))
+	require.NoError(t, err)
+
+	reader, ok := bom.LookupReader("kicad")
+	require.True(t, ok)
+
+	entries, err := reader.Read(strings.NewReader(`"Reference","Value","Footprint","LCSC PN"
+"C1,C2,C3","100n","Capacitor_SMD:C_0603_1608Metric","C14663"
+`))
+	require.NoError(t, err)
+	require.Empty(t, bom.Check(entries))
+
+	lines, total := jlcpcb.EstimateCost(jlcpcb.ConvertBOM(entries, nil), prices)
+	require.Len(t, lines, 1)
+
+	assert.Equal(t, 3, lines[0].Qty)
+	assert.InDelta(t, 0.0063, total, 0.000001)
+}
@@ -25,8 +25,29 @@ type UnmarshallableRegexp struct {
 }
 
 func (rx *UnmarshallableRegexp) UnmarshalText(text []byte) error {
+	// An empty pattern means "not specified" (rather than "match anything").
+	if len(text) == 0 {
+		*rx = UnmarshallableRegexp{}
+		return nil
+	}
+
 	var err error
 	rx.Regexp, err = regexp.Compile(string(text))
 	rx.Length = len(text)
 	return err
 }
+
+func (rx UnmarshallableRegexp) MarshalText() ([]byte, error) {
+	if rx.Regexp == nil {
+		return nil, nil
+	}
+	return []byte(rx.Regexp.String()), nil
+}
+
+// String returns the pattern (or an empty string if there isn't one).
+func (rx UnmarshallableRegexp) String() string {
+	if rx.Regexp == nil {
+		return ""
+	}
+	return rx.Regexp.String()
+}
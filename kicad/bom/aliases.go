@@ -0,0 +1,137 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AliasFields are the BOM entry fields that can be read from other columns
+// (see ApplyFieldAliases).
+var AliasFields = []string{"reference", "value", "footprint", "qty", "lcsc", "dnp"}
+
+// ApplyFieldAliases fills in the fields of BOM entries from other columns, for
+// BOMs that don't use KiCad's column names. Aliases maps a field (one of
+// AliasFields) onto the names of the columns to read it from, the first column
+// that an entry has a value for is used. Fields that are already set are kept,
+// and the columns that were used are removed from the entry's user defined
+// fields.
+func ApplyFieldAliases(entries []Entry, aliases map[string][]string) ([]Entry, error) {
+	for field := range aliases {
+		if !isAliasField(field) {
+			return nil, fmt.Errorf("unknown BOM field: %s", field)
+		}
+	}
+
+	aliased := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		for _, field := range AliasFields {
+			if isSet(entry, field) {
+				continue
+			}
+
+			column, value, ok := aliasValue(entry, aliases[field])
+			if !ok {
+				continue
+			}
+
+			if err := setField(&entry, field, value); err != nil {
+				return nil, fmt.Errorf("entry %q: column %s: %w", entry.Reference, column, err)
+			}
+
+			fields := make(map[string]string, len(entry.Fields))
+			for name, value := range entry.Fields {
+				if name != column {
+					fields[name] = value
+				}
+			}
+			entry.Fields = fields
+		}
+
+		aliased = append(aliased, entry)
+	}
+
+	return aliased, nil
+}
+
+func isAliasField(field string) bool {
+	for _, name := range AliasFields {
+		if name == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSet reports whether a field of an entry already has a value.
+func isSet(entry Entry, field string) bool {
+	switch field {
+	case "reference":
+		return len(entry.Reference) > 0
+	case "value":
+		return entry.Value != ""
+	case "footprint":
+		return entry.Footprint != ""
+	case "qty":
+		return entry.Qty != 0
+	case "lcsc":
+		return entry.LCSC != ""
+	default:
+		return entry.DNP != Populate
+	}
+}
+
+// aliasValue finds the first of the named columns that an entry has a value
+// for, returning the column's name (as it appears in the BOM) and value.
+func aliasValue(entry Entry, columns []string) (string, string, bool) {
+	for _, column := range columns {
+		for name, value := range entry.Fields {
+			if strings.EqualFold(name, column) && value != "" {
+				return name, value, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func setField(entry *Entry, field, value string) error {
+	switch field {
+	case "reference":
+		return entry.Reference.UnmarshalText([]byte(value))
+	case "value":
+		entry.Value = value
+	case "footprint":
+		entry.Footprint = value
+	case "qty":
+		qty, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid quantity: %q", value)
+		}
+		entry.Qty = qty
+	case "lcsc":
+		entry.LCSC = value
+	case "dnp":
+		return entry.DNP.UnmarshalText([]byte(value))
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFieldAliases(t *testing.T) {
+	entries, err := csvx.Unmarshal[bom.Entry](strings.NewReader(`"Reference","Value","Footprint","Quantity","JLCPCB Part #","LCSC PN","Fitted","MPN"
+"R1,R2","10k","R_0603","2","C25804","","","RC0603FR-0710KL"
+"C1","100n","C_0603","1","C1525","C14663","DNF",""
+`))
+	require.NoError(t, err)
+
+	entries, err = bom.ApplyFieldAliases(entries, map[string][]string{
+		"qty":  {"Quantity"},
+		"lcsc": {"LCSC#", "jlcpcb part #"},
+		"dnp":  {"Fitted"},
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, 2, entries[0].Qty)
+	assert.Equal(t, "C25804", entries[0].LCSC)
+	assert.Equal(t, bom.Populate, entries[0].DNP)
+	assert.Equal(t, map[string]string{"MPN": "RC0603FR-0710KL"}, entries[0].Fields)
+
+	// Fields that are already set are kept.
+	assert.Equal(t, "C14663", entries[1].LCSC)
+	assert.Equal(t, bom.DoNotPopulate, entries[1].DNP)
+	assert.Equal(t, map[string]string{"JLCPCB Part #": "C1525"}, entries[1].Fields)
+
+	_, err = bom.ApplyFieldAliases(entries, map[string][]string{"mpn": {"MPN"}})
+	assert.Error(t, err)
+}
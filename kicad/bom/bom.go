@@ -20,17 +20,39 @@ package bom
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/dpeckett/jlcfabtool/csvx"
 )
 
 // Entry represents a single row in a KiCad BOM CSV file.
 type Entry struct {
-	Reference string `csv:"Reference"`
-	Value     string `csv:"Value"`
-	Footprint string `csv:"Footprint"`
-	Qty       int    `csv:"Qty"`
-	LCSC      string `csv:"LCSC PN"`
+	Reference Designators `csv:"Reference" json:"reference" yaml:"reference"`
+	Value     string      `csv:"Value" json:"value" yaml:"value"`
+	Footprint string      `csv:"Footprint" json:"footprint" yaml:"footprint"`
+	Qty       int         `csv:"Qty" json:"qty" yaml:"qty"`
+	LCSC      string      `csv:"LCSC PN" json:"lcsc,omitempty" yaml:"lcsc,omitempty"`
+	DNP       DNP         `csv:"DNP" json:"dnp,omitempty" yaml:"dnp,omitempty"`
+	// Fields holds any other (user defined) columns, eg. MPN or Manufacturer.
+	// Empty cells are omitted.
+	Fields map[string]string `csv:",extra" json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// Field returns the value of a user defined field (ignoring case), or an empty
+// string if the entry doesn't have it.
+func (e Entry) Field(name string) string {
+	if value, ok := e.Fields[name]; ok {
+		return value
+	}
+
+	for key, value := range e.Fields {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+
+	return ""
 }
 
 // LoadFromCSV loads a KiCad BOM from a CSV file.
@@ -48,3 +70,48 @@ func LoadFromCSV(path string) ([]Entry, error) {
 
 	return entries, nil
 }
+
+// Check cross-checks BOM entries against their designators. It reports entries
+// whose quantity disagrees with the number of designators, entries with an
+// unknown DNP value (which are treated as populated), and designators that
+// appear on more than one entry.
+func Check(entries []Entry) []string {
+	var warnings []string
+
+	seen := make(map[string]int)
+	for i, entry := range entries {
+		if entry.Qty != 0 && entry.Qty != len(entry.Reference) {
+			warnings = append(warnings, fmt.Sprintf("entry %q has a quantity of %d but %d designators",
+				entry.Reference, entry.Qty, len(entry.Reference)))
+		}
+
+		if !entry.DNP.Known() {
+			warnings = append(warnings, fmt.Sprintf("entry %q has an unknown DNP value %q, treating it as populated",
+				entry.Reference, string(entry.DNP)))
+		}
+
+		for _, ref := range entry.Reference {
+			seen[ref]++
+		}
+
+		if len(entry.Reference) == 0 {
+			warnings = append(warnings, fmt.Sprintf("entry %d (%s) has no designators", i+1, entry.Value))
+		}
+	}
+
+	var duplicates []string
+	for ref, count := range seen {
+		if count > 1 {
+			duplicates = append(duplicates, ref)
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return NaturalLess(duplicates[i], duplicates[j])
+	})
+
+	for _, ref := range duplicates {
+		warnings = append(warnings, fmt.Sprintf("designator %s appears on %d entries", ref, seen[ref]))
+	}
+
+	return warnings
+}
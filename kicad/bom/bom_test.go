@@ -32,16 +32,39 @@ func TestLoadFromCSV(t *testing.T) {
 	require.Len(t, entries, 6)
 
 	// First record
-	assert.Equal(t, "C1,C2,C3,C4,C7,C10", entries[0].Reference)
+	assert.Equal(t, bom.Designators{"C1", "C2", "C3", "C4", "C7", "C10"}, entries[0].Reference)
 	assert.Equal(t, "100n", entries[0].Value)
 	assert.Equal(t, "Capacitor_SMD:C_0603_1608Metric", entries[0].Footprint)
 	assert.Equal(t, 6, entries[0].Qty)
 	assert.Equal(t, "C14663", entries[0].LCSC)
 
 	// Last record
-	assert.Equal(t, "J2", entries[5].Reference)
+	assert.Equal(t, bom.Designators{"J2"}, entries[5].Reference)
 	assert.Equal(t, "Boot Selection", entries[5].Value)
 	assert.Equal(t, "Connector_PinHeader_2.54mm:PinHeader_1x03_P2.54mm_Vertical", entries[5].Footprint)
 	assert.Equal(t, 1, entries[5].Qty)
 	assert.Empty(t, entries[5].LCSC)
 }
+
+func TestLoadFromCSVFields(t *testing.T) {
+	entries, err := bom.LoadFromCSV("testdata/fields.csv")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, map[string]string{"MPN": "AMS1117-3.3", "Manufacturer": "AMS"}, entries[0].Fields)
+	assert.Equal(t, "AMS", entries[0].Field("manufacturer"))
+
+	assert.Empty(t, entries[1].Fields)
+	assert.Empty(t, entries[1].Field("MPN"))
+}
+
+func TestCheck(t *testing.T) {
+	warnings := bom.Check([]bom.Entry{
+		{Reference: bom.Designators{"C1", "C2"}, Value: "100n", Qty: 2},
+		{Reference: bom.Designators{"C2", "C3"}, Value: "1u", Qty: 3},
+	})
+
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "quantity of 3 but 2 designators")
+	assert.Contains(t, warnings[1], "designator C2 appears on 2 entries")
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Consolidate regroups BOM entries so that there is a single entry per LCSC
+// part number. Entries without a part number are grouped by value and footprint
+// instead. Designators are merged (in natural order) and quantities recomputed.
+// Any conflicting values or footprints within a group are returned as warnings,
+// the first value seen is kept. User defined fields are combined, again keeping
+// the first value seen.
+func Consolidate(entries []Entry) ([]Entry, []string) {
+	var warnings []string
+
+	var groups []*Entry
+	index := make(map[string]*Entry)
+
+	for _, entry := range entries {
+		key := groupKey(entry)
+
+		group, ok := index[key]
+		if !ok {
+			group = &Entry{
+				Value:     entry.Value,
+				Footprint: entry.Footprint,
+				LCSC:      entry.LCSC,
+			}
+			index[key] = group
+			groups = append(groups, group)
+		} else {
+			if entry.Value != group.Value {
+				warnings = append(warnings, fmt.Sprintf("LCSC part %s has conflicting values %q and %q (keeping %q)",
+					group.LCSC, group.Value, entry.Value, group.Value))
+			}
+			if entry.Footprint != group.Footprint {
+				warnings = append(warnings, fmt.Sprintf("LCSC part %s has conflicting footprints %q and %q (keeping %q)",
+					group.LCSC, group.Footprint, entry.Footprint, group.Footprint))
+			}
+		}
+
+		group.Reference = group.Reference.Merge(entry.Reference)
+		group.Fields = mergeFields(group.Fields, entry.Fields)
+	}
+
+	consolidated := make([]Entry, 0, len(groups))
+	for _, group := range groups {
+		group.Qty = len(group.Reference)
+		consolidated = append(consolidated, *group)
+	}
+
+	return consolidated, warnings
+}
+
+// groupKey returns the key used to group equivalent entries.
+func groupKey(entry Entry) string {
+	if entry.LCSC != "" {
+		return "lcsc:" + strings.ToUpper(entry.LCSC)
+	}
+
+	return "value:" + entry.Value + "\x00" + entry.Footprint
+}
+
+// mergeFields adds the fields of from that are missing from into.
+func mergeFields(into, from map[string]string) map[string]string {
+	for key, value := range from {
+		if _, ok := into[key]; ok {
+			continue
+		}
+
+		if into == nil {
+			into = make(map[string]string, len(from))
+		}
+		into[key] = value
+	}
+
+	return into
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsolidate(t *testing.T) {
+	entries, warnings := bom.Consolidate([]bom.Entry{
+		{Reference: bom.Designators{"C2", "C10"}, Value: "100n", Footprint: "Capacitor_SMD:C_0603_1608Metric", Qty: 2, LCSC: "C14663"},
+		{Reference: bom.Designators{"R1"}, Value: "10k", Footprint: "Resistor_SMD:R_0603_1608Metric", Qty: 1},
+		{Reference: bom.Designators{"C1"}, Value: "0.1u", Footprint: "Capacitor_SMD:C_0603_1608Metric", Qty: 1, LCSC: "C14663"},
+		{Reference: bom.Designators{"R3", "R12"}, Value: "10k", Footprint: "Resistor_SMD:R_0603_1608Metric", Qty: 2},
+		{Reference: bom.Designators{"R4"}, Value: "10k", Footprint: "Resistor_SMD:R_0402_1005Metric", Qty: 1},
+	})
+
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "C1,C2,C10", entries[0].Reference.String())
+	assert.Equal(t, "100n", entries[0].Value)
+	assert.Equal(t, 3, entries[0].Qty)
+	assert.Equal(t, "C14663", entries[0].LCSC)
+
+	assert.Equal(t, "R1,R3,R12", entries[1].Reference.String())
+	assert.Equal(t, "Resistor_SMD:R_0603_1608Metric", entries[1].Footprint)
+	assert.Equal(t, 3, entries[1].Qty)
+
+	assert.Equal(t, "R4", entries[2].Reference.String())
+	assert.Equal(t, 1, entries[2].Qty)
+
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "C14663")
+}
+
+func TestConsolidateDuplicateDesignators(t *testing.T) {
+	entries, warnings := bom.Consolidate([]bom.Entry{
+		{Reference: bom.Designators{"U1"}, Value: "AMS1117-3.3", Qty: 1, LCSC: "C6186"},
+		{Reference: bom.Designators{"U1", "U2"}, Value: "AMS1117-3.3", Qty: 2, LCSC: "c6186"},
+	})
+
+	require.Len(t, entries, 1)
+	assert.Empty(t, warnings)
+
+	assert.Equal(t, "U1,U2", entries[0].Reference.String())
+	assert.Equal(t, 2, entries[0].Qty)
+}
+
+func TestConsolidateFields(t *testing.T) {
+	entries, _ := bom.Consolidate([]bom.Entry{
+		{Reference: bom.Designators{"U1"}, Value: "AMS1117-3.3", LCSC: "C6186", Fields: map[string]string{"MPN": "AMS1117-3.3"}},
+		{Reference: bom.Designators{"U2"}, Value: "AMS1117-3.3", LCSC: "C6186", Fields: map[string]string{"MPN": "other", "Manufacturer": "AMS"}},
+	})
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]string{"MPN": "AMS1117-3.3", "Manufacturer": "AMS"}, entries[0].Fields)
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxRangeSize limits how many designators a single range may expand to.
+const maxRangeSize = 10000
+
+var designatorRangeRegexp = regexp.MustCompile(`^([A-Za-z_]+)(\d+)-([A-Za-z_]+)(\d+)$`)
+
+// Designators is a naturally sorted, de-duplicated set of reference designators.
+type Designators []string
+
+// ParseDesignators parses a list of reference designators. Designators may be
+// separated by commas or whitespace and ranges (eg. "R1-R5") are expanded. A
+// hyphen is only treated as a range if both sides have the same prefix, so
+// designators such as "J1-A" or "R1-C5" are kept as is. Reversed ranges (eg.
+// "R5-R1") are rejected.
+func ParseDesignators(s string) (Designators, error) {
+	var d Designators
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+
+	for _, field := range fields {
+		m := designatorRangeRegexp.FindStringSubmatch(field)
+		if m == nil || m[1] != m[3] {
+			d = append(d, field)
+			continue
+		}
+
+		prefix := m[1]
+
+		start, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid designator range %q: %w", field, err)
+		}
+
+		end, err := strconv.Atoi(m[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid designator range %q: %w", field, err)
+		}
+
+		if start > end {
+			return nil, fmt.Errorf("invalid designator range %q: reversed", field)
+		}
+
+		if end-start >= maxRangeSize {
+			return nil, fmt.Errorf("invalid designator range %q: too many designators", field)
+		}
+
+		for i := start; i <= end; i++ {
+			d = append(d, prefix+strconv.Itoa(i))
+		}
+	}
+
+	return d.normalize(), nil
+}
+
+// Merge returns the union of two sets of designators.
+func (d Designators) Merge(other Designators) Designators {
+	merged := make(Designators, 0, len(d)+len(other))
+	merged = append(merged, d...)
+	merged = append(merged, other...)
+	return merged.normalize()
+}
+
+// Contains reports whether the set contains the given designator.
+func (d Designators) Contains(ref string) bool {
+	i := sort.Search(len(d), func(i int) bool {
+		return !NaturalLess(d[i], ref)
+	})
+	return i < len(d) && d[i] == ref
+}
+
+// String returns the designators as an explicit comma separated list.
+func (d Designators) String() string {
+	return strings.Join(d, ",")
+}
+
+func (d Designators) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Designators) UnmarshalText(text []byte) error {
+	var err error
+	*d, err = ParseDesignators(string(text))
+	return err
+}
+
+// normalize sorts the designators in natural order and removes duplicates.
+func (d Designators) normalize() Designators {
+	sort.SliceStable(d, func(i, j int) bool {
+		return NaturalLess(d[i], d[j])
+	})
+
+	var unique Designators
+	for i, ref := range d {
+		if i > 0 && ref == d[i-1] {
+			continue
+		}
+		unique = append(unique, ref)
+	}
+	return unique
+}
+
+// NaturalLess compares two strings (eg. designators), treating runs of digits
+// as numbers (eg. C2 sorts before C10).
+func NaturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := isDigit(a[0]), isDigit(b[0])
+
+		switch {
+		case aDigits && bDigits:
+			var aNum, bNum string
+			aNum, a = splitDigits(a)
+			bNum, b = splitDigits(b)
+
+			// Compare numerically, ignoring leading zeros.
+			aTrimmed, bTrimmed := strings.TrimLeft(aNum, "0"), strings.TrimLeft(bNum, "0")
+			if len(aTrimmed) != len(bTrimmed) {
+				return len(aTrimmed) < len(bTrimmed)
+			}
+			if aTrimmed != bTrimmed {
+				return aTrimmed < bTrimmed
+			}
+			if len(aNum) != len(bNum) {
+				return len(aNum) < len(bNum)
+			}
+		case aDigits != bDigits:
+			return aDigits
+		default:
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+			a, b = a[1:], b[1:]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+func splitDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
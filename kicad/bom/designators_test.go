@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDesignators(t *testing.T) {
+	d, err := bom.ParseDesignators("R10, R2,R1-R3 C1;R5-R7")
+	require.NoError(t, err)
+
+	assert.Equal(t, bom.Designators{"C1", "R1", "R2", "R3", "R5", "R6", "R7", "R10"}, d)
+	assert.Equal(t, "C1,R1,R2,R3,R5,R6,R7,R10", d.String())
+
+	assert.True(t, d.Contains("R6"))
+	assert.False(t, d.Contains("R4"))
+}
+
+func TestParseDesignatorsEmpty(t *testing.T) {
+	d, err := bom.ParseDesignators("")
+	require.NoError(t, err)
+
+	assert.Empty(t, d)
+}
+
+func TestParseDesignatorsHyphenated(t *testing.T) {
+	// Only hyphens between designators with the same prefix are ranges.
+	d, err := bom.ParseDesignators("J1-A,R1-C5,R5-7")
+	require.NoError(t, err)
+
+	assert.Equal(t, bom.Designators{"J1-A", "R1-C5", "R5-7"}, d)
+}
+
+func TestParseDesignatorsInvalidRange(t *testing.T) {
+	_, err := bom.ParseDesignators("R5-R1")
+	require.Error(t, err)
+
+	_, err = bom.ParseDesignators("R1-R100000")
+	require.Error(t, err)
+}
+
+func TestDesignatorsMerge(t *testing.T) {
+	d := bom.Designators{"U1", "U10"}.Merge(bom.Designators{"U2", "U10"})
+
+	assert.Equal(t, bom.Designators{"U1", "U2", "U10"}, d)
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+	// ChangeDNP is a part that changed between fitted and DNP.
+	ChangeDNP = "dnp"
+)
+
+// Part is the part fitted to a single designator.
+type Part struct {
+	Value     string `json:"value" yaml:"value"`
+	Footprint string `json:"footprint" yaml:"footprint"`
+	LCSC      string `json:"lcsc,omitempty" yaml:"lcsc,omitempty"`
+	DNP       bool   `json:"dnp,omitempty" yaml:"dnp,omitempty"`
+}
+
+// DesignatorChange describes how the part fitted to a designator changed
+// between two revisions of a BOM.
+type DesignatorChange struct {
+	Designator string `json:"designator" yaml:"designator"`
+	// Change is one of "added", "removed", "changed" or "dnp" (if the part
+	// changed between fitted and DNP, along with any other fields).
+	Change string `json:"change" yaml:"change"`
+	// Fields lists the fields that differ (value, footprint, lcsc and/or dnp).
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Old    *Part    `json:"old,omitempty" yaml:"old,omitempty"`
+	New    *Part    `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Diff compares two revisions of a BOM designator by designator. Changes are
+// returned in natural designator order. Designators that appear on more than
+// one entry of a revision are reported as warnings (and compared using their
+// first entry).
+func Diff(old, new []Entry) ([]DesignatorChange, []string) {
+	oldParts, warnings := partsByDesignator(old, "old")
+	newParts, newWarnings := partsByDesignator(new, "new")
+	warnings = append(warnings, newWarnings...)
+
+	var changes []DesignatorChange
+
+	for ref, oldPart := range oldParts {
+		newPart, ok := newParts[ref]
+		if !ok {
+			changes = append(changes, DesignatorChange{
+				Designator: ref,
+				Change:     ChangeRemoved,
+				Old:        &oldPart,
+			})
+			continue
+		}
+
+		var fields []string
+		if oldPart.Value != newPart.Value {
+			fields = append(fields, "value")
+		}
+		if oldPart.Footprint != newPart.Footprint {
+			fields = append(fields, "footprint")
+		}
+		if oldPart.LCSC != newPart.LCSC {
+			fields = append(fields, "lcsc")
+		}
+
+		change := ChangeChanged
+		if oldPart.DNP != newPart.DNP {
+			change = ChangeDNP
+			fields = append(fields, "dnp")
+		}
+
+		if len(fields) > 0 {
+			changes = append(changes, DesignatorChange{
+				Designator: ref,
+				Change:     change,
+				Fields:     fields,
+				Old:        &oldPart,
+				New:        &newPart,
+			})
+		}
+	}
+
+	for ref, newPart := range newParts {
+		if _, ok := oldParts[ref]; !ok {
+			changes = append(changes, DesignatorChange{
+				Designator: ref,
+				Change:     ChangeAdded,
+				New:        &newPart,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return NaturalLess(changes[i].Designator, changes[j].Designator)
+	})
+
+	return changes, warnings
+}
+
+// partsByDesignator maps the designators of a revision onto their parts, with
+// warnings for designators that appear on more than one entry.
+func partsByDesignator(entries []Entry, revision string) (map[string]Part, []string) {
+	parts := make(map[string]Part)
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		for _, ref := range entry.Reference {
+			counts[ref]++
+			if counts[ref] > 1 {
+				continue
+			}
+
+			parts[ref] = Part{
+				Value:     entry.Value,
+				Footprint: entry.Footprint,
+				LCSC:      entry.LCSC,
+				DNP:       entry.DNP == DoNotPopulate,
+			}
+		}
+	}
+
+	var duplicates []string
+	for ref, count := range counts {
+		if count > 1 {
+			duplicates = append(duplicates, ref)
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return NaturalLess(duplicates[i], duplicates[j])
+	})
+
+	var warnings []string
+	for _, ref := range duplicates {
+		warnings = append(warnings, fmt.Sprintf("designator %s appears on %d entries of the %s BOM, comparing the first",
+			ref, counts[ref], revision))
+	}
+
+	return parts, warnings
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	changes, warnings := bom.Diff([]bom.Entry{
+		{Reference: bom.Designators{"C1", "C2", "C10"}, Value: "100n", Footprint: "C_0603_1608Metric", LCSC: "C14663"},
+		{Reference: bom.Designators{"R1"}, Value: "10k", Footprint: "R_0603_1608Metric", LCSC: "C25804"},
+	}, []bom.Entry{
+		{Reference: bom.Designators{"C1", "C10"}, Value: "100n", Footprint: "C_0603_1608Metric", LCSC: "C14663"},
+		{Reference: bom.Designators{"C3"}, Value: "1u", Footprint: "C_0603_1608Metric", LCSC: "C15849"},
+		{Reference: bom.Designators{"R1"}, Value: "4.7k", Footprint: "R_0603_1608Metric", LCSC: "C23162"},
+	})
+
+	require.Len(t, changes, 3)
+	assert.Empty(t, warnings)
+
+	assert.Equal(t, "C2", changes[0].Designator)
+	assert.Equal(t, bom.ChangeRemoved, changes[0].Change)
+	assert.Equal(t, "100n", changes[0].Old.Value)
+	assert.Nil(t, changes[0].New)
+
+	assert.Equal(t, "C3", changes[1].Designator)
+	assert.Equal(t, bom.ChangeAdded, changes[1].Change)
+	assert.Equal(t, "C15849", changes[1].New.LCSC)
+
+	assert.Equal(t, "R1", changes[2].Designator)
+	assert.Equal(t, bom.ChangeChanged, changes[2].Change)
+	assert.Equal(t, []string{"value", "lcsc"}, changes[2].Fields)
+	assert.Equal(t, "10k", changes[2].Old.Value)
+	assert.Equal(t, "4.7k", changes[2].New.Value)
+}
+
+func TestDiffDNP(t *testing.T) {
+	changes, warnings := bom.Diff([]bom.Entry{
+		{Reference: bom.Designators{"R1", "R2"}, Value: "10k", Footprint: "R_0603_1608Metric", LCSC: "C25804"},
+	}, []bom.Entry{
+		{Reference: bom.Designators{"R1"}, Value: "10k", Footprint: "R_0603_1608Metric", LCSC: "C25804"},
+		{Reference: bom.Designators{"R2"}, Value: "10k", Footprint: "R_0603_1608Metric", LCSC: "C25804", DNP: bom.DoNotPopulate},
+	})
+	assert.Empty(t, warnings)
+
+	require.Len(t, changes, 1)
+	assert.Equal(t, "R2", changes[0].Designator)
+	assert.Equal(t, bom.ChangeDNP, changes[0].Change)
+	assert.Equal(t, []string{"dnp"}, changes[0].Fields)
+	assert.False(t, changes[0].Old.DNP)
+	assert.True(t, changes[0].New.DNP)
+}
+
+func TestDiffDuplicateDesignators(t *testing.T) {
+	changes, warnings := bom.Diff([]bom.Entry{
+		{Reference: bom.Designators{"R1"}, Value: "10k", Footprint: "R_0603_1608Metric"},
+	}, []bom.Entry{
+		{Reference: bom.Designators{"R1"}, Value: "10k", Footprint: "R_0603_1608Metric"},
+		{Reference: bom.Designators{"R1"}, Value: "4.7k", Footprint: "R_0603_1608Metric"},
+	})
+
+	assert.Empty(t, changes)
+	assert.Equal(t, []string{"designator R1 appears on 2 entries of the new BOM, comparing the first"}, warnings)
+}
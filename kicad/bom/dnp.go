@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DNP marks a BOM entry as "do not populate". KiCad writes "DNP" in the column
+// for parts that are not to be fitted (and leaves it empty otherwise), other
+// tools use variants such as "DNF", "NC" or "Do not populate". Recognized
+// values are normalized to DoNotPopulate or Populate, unknown values are kept
+// as they are (and treated as populated, see Check).
+type DNP string
+
+const (
+	// Populate marks a part that is to be fitted.
+	Populate DNP = ""
+	// DoNotPopulate marks a part that is not to be fitted.
+	DoNotPopulate DNP = "DNP"
+)
+
+// Known reports whether the value is a recognized DNP value.
+func (d DNP) Known() bool {
+	return d == Populate || d == DoNotPopulate
+}
+
+func (d DNP) MarshalText() ([]byte, error) {
+	if d == DoNotPopulate {
+		return []byte(DoNotPopulate), nil
+	}
+	return []byte{}, nil
+}
+
+func (d *DNP) UnmarshalText(text []byte) error {
+	// Normalize case, hyphens, underscores and whitespace (eg. "Do-Not-Fit").
+	value := strings.Join(strings.FieldsFunc(strings.ToLower(string(text)), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_'
+	}), " ")
+
+	switch value {
+	case "", "0", "no", "false", "fit", "fitted", "populate", "populated":
+		*d = Populate
+	case "dnp", "dnf", "dni", "nc", "n/c", "nf", "x", "1", "yes", "true",
+		"do not populate", "do not fit", "do not install", "do not place",
+		"not populated", "not fitted", "no fit":
+		*d = DoNotPopulate
+	default:
+		*d = DNP(strings.TrimSpace(string(text)))
+	}
+
+	return nil
+}
+
+// SplitDNP separates the entries that are to be fitted from those marked DNP.
+func SplitDNP(entries []Entry) (fitted, dnp []Entry) {
+	for _, entry := range entries {
+		if entry.DNP == DoNotPopulate {
+			dnp = append(dnp, entry)
+		} else {
+			fitted = append(fitted, entry)
+		}
+	}
+
+	return fitted, dnp
+}
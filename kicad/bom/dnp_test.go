@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNP(t *testing.T) {
+	entries, err := csvx.Unmarshal[bom.Entry](strings.NewReader(`"Reference","Value","Footprint","Qty","DNP","LCSC PN"
+"R1","10k","R_0603","1","","C25804"
+"R2","DNP","R_0603","1","DNP","C25804"
+`))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, bom.Populate, entries[0].DNP)
+	assert.Equal(t, bom.DoNotPopulate, entries[1].DNP)
+
+	fitted, dnp := bom.SplitDNP(entries)
+	require.Len(t, fitted, 1)
+	require.Len(t, dnp, 1)
+	assert.Equal(t, "R1", fitted[0].Reference.String())
+	assert.Equal(t, "R2", dnp[0].Reference.String())
+
+}
+
+func TestDNPUnmarshalText(t *testing.T) {
+	for _, value := range []string{"DNP", "dnf", "NC", "n/c", "Do not populate", "DO-NOT-FIT", "do_not_place", "x"} {
+		var d bom.DNP
+		require.NoError(t, d.UnmarshalText([]byte(value)), value)
+		assert.Equal(t, bom.DoNotPopulate, d, value)
+	}
+
+	for _, value := range []string{"", " ", "no", "Fitted", "0"} {
+		d := bom.DoNotPopulate
+		require.NoError(t, d.UnmarshalText([]byte(value)), value)
+		assert.Equal(t, bom.Populate, d, value)
+	}
+
+	// Unknown values are kept, and treated as populated.
+	var d bom.DNP
+	require.NoError(t, d.UnmarshalText([]byte("maybe")))
+	assert.False(t, d.Known())
+	assert.NotEqual(t, bom.DoNotPopulate, d)
+}
+
+func TestCheckUnknownDNP(t *testing.T) {
+	entries, err := csvx.Unmarshal[bom.Entry](strings.NewReader(`"Reference","Value","Footprint","Qty","DNP","LCSC PN"
+"R1","10k","R_0603","1","maybe","C25804"
+`))
+	require.NoError(t, err)
+
+	fitted, dnp := bom.SplitDNP(entries)
+	assert.Len(t, fitted, 1)
+	assert.Empty(t, dnp)
+
+	assert.Equal(t, []string{`entry "R1" has an unknown DNP value "maybe", treating it as populated`}, bom.Check(entries))
+}
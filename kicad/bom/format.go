@@ -0,0 +1,113 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/internal/registry"
+)
+
+// sniffLen is how much of a file is passed to Reader.Sniff.
+const sniffLen = registry.SniffLen
+
+// Reader reads BOM entries from a particular EDA tool's output.
+// Readers for additional formats can be registered with RegisterReader, eg.
+//
+//	func init() {
+//		bom.RegisterReader(&myReader{})
+//	}
+type Reader interface {
+	// Name is the short name of the format (eg. "kicad").
+	Name() string
+	// Sniff reports whether a file is in this format, based on its name and
+	// the first few kilobytes of its content.
+	Sniff(name string, head []byte) bool
+	// Read reads all the BOM entries from r.
+	Read(r io.Reader) ([]Entry, error)
+}
+
+var readers = registry.New[Entry]("BOM")
+
+func init() {
+	RegisterReader(&kicadCSVReader{})
+}
+
+// RegisterReader registers a BOM reader. Readers are sniffed
+// in the order they were registered. It panics if a reader with the same name
+// is already registered.
+func RegisterReader(r Reader) {
+	if err := readers.Register(r); err != nil {
+		panic(err)
+	}
+}
+
+// Readers returns all the registered BOM readers.
+func Readers() []Reader {
+	registered := readers.Readers()
+
+	rs := make([]Reader, 0, len(registered))
+	for _, r := range registered {
+		rs = append(rs, r)
+	}
+
+	return rs
+}
+
+// LookupReader finds a registered BOM reader by name.
+func LookupReader(name string) (Reader, bool) {
+	return readers.Lookup(name)
+}
+
+// Load loads BOM entries from a file. If format is empty, it will be detected
+// from the file's name and content. The name of the format is returned alongside
+// the entries.
+func Load(path, format string) ([]Entry, string, error) {
+	return readers.Load(path, format)
+}
+
+// ReadFile reads a BOM file using a specific reader (eg. one configured with
+// reader specific options), bypassing format detection.
+func ReadFile(path string, reader Reader) ([]Entry, error) {
+	return readers.ReadFile(path, reader)
+}
+
+// kicadCSVReader reads KiCad BOM CSV files.
+type kicadCSVReader struct{}
+
+func (r *kicadCSVReader) Name() string {
+	return "kicad"
+}
+
+func (r *kicadCSVReader) Sniff(name string, head []byte) bool {
+	header, _, _ := bytes.Cut(csvx.DecodeText(head), []byte("\n"))
+	return strings.Contains(string(header), "Reference") && strings.Contains(string(header), "Value")
+}
+
+func (r *kicadCSVReader) Read(rd io.Reader) ([]Entry, error) {
+	entries, err := csvx.Unmarshal[Entry](rd)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+
+	return entries, nil
+}
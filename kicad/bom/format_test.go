@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReader struct{}
+
+func (r *testReader) Name() string {
+	return "test"
+}
+
+func (r *testReader) Sniff(name string, head []byte) bool {
+	return bytes.HasPrefix(head, []byte("TEST"))
+}
+
+func (r *testReader) Read(rd io.Reader) ([]bom.Entry, error) {
+	return []bom.Entry{{Reference: bom.Designators{"T1"}, Qty: 1}}, nil
+}
+
+func init() {
+	bom.RegisterReader(&testReader{})
+}
+
+func TestLoad(t *testing.T) {
+	entries, format, err := bom.Load("testdata/bom.csv", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "kicad", format)
+	assert.Len(t, entries, 6)
+}
+
+func TestLoadRegisteredReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.test")
+	require.NoError(t, os.WriteFile(path, []byte("TEST\n"), 0o644))
+
+	entries, format, err := bom.Load(path, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", format)
+	assert.Equal(t, []bom.Entry{{Reference: bom.Designators{"T1"}, Qty: 1}}, entries)
+
+	_, _, err = bom.Load("testdata/bom.csv", "unknown")
+	require.Error(t, err)
+}
+
+func TestRegisterReaderDuplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		bom.RegisterReader(&testReader{})
+	})
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom
+
+import "fmt"
+
+// Board is a single board (and the number of copies being built) in a
+// combined order.
+type Board struct {
+	Name     string
+	Quantity int
+	Entries  []Entry
+}
+
+// PurchaseLine is a single line item in a combined purchasing summary.
+type PurchaseLine struct {
+	LCSC      string          `json:"lcsc,omitempty" yaml:"lcsc,omitempty"`
+	Value     string          `json:"value" yaml:"value"`
+	Footprint string          `json:"footprint" yaml:"footprint"`
+	Qty       int             `json:"qty" yaml:"qty"`
+	Boards    []BoardQuantity `json:"boards" yaml:"boards"`
+}
+
+// BoardQuantity is the contribution of a single board to a purchase line.
+type BoardQuantity struct {
+	Board string `json:"board" yaml:"board"`
+	// Index is the position of the board in the merged boards (board names
+	// need not be unique).
+	Index    int `json:"index" yaml:"index"`
+	PerBoard int `json:"per_board" yaml:"per_board"`
+	Qty      int `json:"qty" yaml:"qty"`
+}
+
+// Merge combines the BOMs of several boards into a single purchasing summary.
+// Entries are grouped in the same way as Consolidate and multiplied by the
+// number of copies of each board. Parts marked DNP are excluded. Conflicting
+// values or footprints are returned as warnings.
+func Merge(boards []Board) ([]PurchaseLine, []string) {
+	var warnings []string
+
+	var lines []*PurchaseLine
+	index := make(map[string]*PurchaseLine)
+
+	for i, board := range boards {
+		fitted, _ := SplitDNP(board.Entries)
+
+		entries, boardWarnings := Consolidate(fitted)
+		for _, warning := range boardWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", board.Name, warning))
+		}
+
+		for _, entry := range entries {
+			key := groupKey(entry)
+
+			line, ok := index[key]
+			if !ok {
+				line = &PurchaseLine{
+					LCSC:      entry.LCSC,
+					Value:     entry.Value,
+					Footprint: entry.Footprint,
+				}
+				index[key] = line
+				lines = append(lines, line)
+			} else {
+				if entry.Value != line.Value {
+					warnings = append(warnings, fmt.Sprintf("%s: LCSC part %s has conflicting values %q and %q (keeping %q)",
+						board.Name, line.LCSC, line.Value, entry.Value, line.Value))
+				}
+				if entry.Footprint != line.Footprint {
+					warnings = append(warnings, fmt.Sprintf("%s: LCSC part %s has conflicting footprints %q and %q (keeping %q)",
+						board.Name, line.LCSC, line.Footprint, entry.Footprint, line.Footprint))
+				}
+			}
+
+			qty := entry.Qty * board.Quantity
+
+			line.Qty += qty
+			line.Boards = append(line.Boards, BoardQuantity{
+				Board:    board.Name,
+				Index:    i,
+				PerBoard: entry.Qty,
+				Qty:      qty,
+			})
+		}
+	}
+
+	merged := make([]PurchaseLine, 0, len(lines))
+	for _, line := range lines {
+		merged = append(merged, *line)
+	}
+
+	return merged, warnings
+}
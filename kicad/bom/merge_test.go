@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bom_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	lines, warnings := bom.Merge([]bom.Board{
+		{
+			Name:     "main",
+			Quantity: 5,
+			Entries: []bom.Entry{
+				{Reference: bom.Designators{"C1", "C2"}, Value: "100n", Footprint: "C_0603_1608Metric", Qty: 2, LCSC: "C14663"},
+				{Reference: bom.Designators{"U1"}, Value: "AMS1117-3.3", Footprint: "SOT-223-3_TabPin2", Qty: 1, LCSC: "C6186"},
+			},
+		},
+		{
+			Name:     "daughter",
+			Quantity: 10,
+			Entries: []bom.Entry{
+				{Reference: bom.Designators{"C1"}, Value: "0.1u", Footprint: "C_0603_1608Metric", Qty: 1, LCSC: "C14663"},
+				{Reference: bom.Designators{"J1"}, Value: "Conn", Footprint: "PinHeader_1x03_P2.54mm_Vertical", Qty: 1},
+				{Reference: bom.Designators{"C2"}, Value: "100n", Footprint: "C_0603_1608Metric", Qty: 1, LCSC: "C14663", DNP: bom.DoNotPopulate},
+			},
+		},
+	})
+
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, "C14663", lines[0].LCSC)
+	assert.Equal(t, "100n", lines[0].Value)
+	assert.Equal(t, 20, lines[0].Qty)
+	assert.Equal(t, []bom.BoardQuantity{
+		{Board: "main", Index: 0, PerBoard: 2, Qty: 10},
+		{Board: "daughter", Index: 1, PerBoard: 1, Qty: 10},
+	}, lines[0].Boards)
+
+	assert.Equal(t, "C6186", lines[1].LCSC)
+	assert.Equal(t, 5, lines[1].Qty)
+
+	assert.Empty(t, lines[2].LCSC)
+	assert.Equal(t, 10, lines[2].Qty)
+
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "daughter: LCSC part C14663")
+}
+
+func TestMergeSameName(t *testing.T) {
+	entries := []bom.Entry{
+		{Reference: bom.Designators{"C1"}, Value: "100n", Footprint: "C_0603_1608Metric", Qty: 1, LCSC: "C14663"},
+	}
+
+	lines, _ := bom.Merge([]bom.Board{
+		{Name: "board", Quantity: 2, Entries: entries},
+		{Name: "board", Quantity: 3, Entries: entries},
+	})
+
+	require.Len(t, lines, 1)
+	assert.Equal(t, 5, lines[0].Qty)
+	assert.Equal(t, []bom.BoardQuantity{
+		{Board: "board", Index: 0, PerBoard: 1, Qty: 2},
+		{Board: "board", Index: 1, PerBoard: 1, Qty: 3},
+	}, lines[0].Boards)
+}
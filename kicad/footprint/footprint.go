@@ -0,0 +1,379 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package footprint reads the geometry of footprints from KiCad board
+// (.kicad_pcb) and footprint (.kicad_mod) files.
+package footprint
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/kicad/sexpr"
+)
+
+// Footprint is the geometry of a footprint. Coordinates are relative to the
+// footprint's origin, in millimetres, with the Y axis pointing down (as in
+// KiCad).
+type Footprint struct {
+	// Name is the name of the footprint (without the library nickname).
+	Name    string `json:"name" yaml:"name"`
+	Library string `json:"library,omitempty" yaml:"library,omitempty"`
+	// Ref and Value are only set for footprints read from a board.
+	Ref   string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Side is the side of the board the footprint is placed on ("top" or
+	// "bottom").
+	Side string `json:"side" yaml:"side"`
+	Pads []Pad  `json:"pads" yaml:"pads"`
+	// Courtyard and Fab are the bounding boxes of the courtyard and
+	// fabrication layer graphics (nil if there aren't any).
+	Courtyard *Rect `json:"courtyard,omitempty" yaml:"courtyard,omitempty"`
+	Fab       *Rect `json:"fab,omitempty" yaml:"fab,omitempty"`
+}
+
+// Pad is a pad of a footprint.
+type Pad struct {
+	// Number is empty for pads that aren't connected (eg. mounting holes).
+	Number string  `json:"number" yaml:"number"`
+	X      float64 `json:"x" yaml:"x"`
+	Y      float64 `json:"y" yaml:"y"`
+	Width  float64 `json:"width" yaml:"width"`
+	Height float64 `json:"height" yaml:"height"`
+	// Rotation is the rotation of the pad relative to the footprint.
+	Rotation float64 `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+}
+
+// Bounds returns the bounding box of the pad.
+func (p Pad) Bounds() Rect {
+	theta := p.Rotation * (math.Pi / 180.0)
+	w := math.Abs(p.Width*math.Cos(theta)) + math.Abs(p.Height*math.Sin(theta))
+	h := math.Abs(p.Width*math.Sin(theta)) + math.Abs(p.Height*math.Cos(theta))
+
+	return Rect{MinX: p.X - w/2, MinY: p.Y - h/2, MaxX: p.X + w/2, MaxY: p.Y + h/2}
+}
+
+// Rect is an axis aligned bounding box.
+type Rect struct {
+	MinX float64 `json:"min_x" yaml:"min_x"`
+	MinY float64 `json:"min_y" yaml:"min_y"`
+	MaxX float64 `json:"max_x" yaml:"max_x"`
+	MaxY float64 `json:"max_y" yaml:"max_y"`
+}
+
+// Center returns the center of the rectangle.
+func (r Rect) Center() (float64, float64) {
+	return (r.MinX + r.MaxX) / 2, (r.MinY + r.MaxY) / 2
+}
+
+// Width returns the width of the rectangle.
+func (r Rect) Width() float64 {
+	return r.MaxX - r.MinX
+}
+
+// Height returns the height of the rectangle.
+func (r Rect) Height() float64 {
+	return r.MaxY - r.MinY
+}
+
+// Union returns the bounding box of both rectangles.
+func (r Rect) Union(other Rect) Rect {
+	return Rect{
+		MinX: math.Min(r.MinX, other.MinX),
+		MinY: math.Min(r.MinY, other.MinY),
+		MaxX: math.Max(r.MaxX, other.MaxX),
+		MaxY: math.Max(r.MaxY, other.MaxY),
+	}
+}
+
+// PadBounds returns the bounding box of the pads of a footprint (false if it
+// has no pads).
+func (f Footprint) PadBounds() (Rect, bool) {
+	if len(f.Pads) == 0 {
+		return Rect{}, false
+	}
+
+	bounds := f.Pads[0].Bounds()
+	for _, pad := range f.Pads[1:] {
+		bounds = bounds.Union(pad.Bounds())
+	}
+
+	return bounds, true
+}
+
+// Centroid returns the center of the footprint's body, from the bounding box of
+// its courtyard (or of its pads if it has no courtyard). The source of the
+// centroid is either "courtyard" or "pads" (false if the footprint has neither).
+func (f Footprint) Centroid() (x, y float64, source string, ok bool) {
+	if f.Courtyard != nil {
+		x, y = f.Courtyard.Center()
+		return x, y, "courtyard", true
+	}
+
+	if bounds, ok := f.PadBounds(); ok {
+		x, y = bounds.Center()
+		return x, y, "pads", true
+	}
+
+	return 0, 0, "", false
+}
+
+// Load loads the footprints of a KiCad board (.kicad_pcb) or footprint
+// (.kicad_mod) file.
+func Load(path string) ([]Footprint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Read reads the footprints of a KiCad board (.kicad_pcb) or footprint
+// (.kicad_mod) file. Footprints on the back of a board are mirrored in the
+// board file, so this is undone to match the library footprint.
+func Read(r io.Reader) ([]Footprint, error) {
+	root, err := sexpr.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root.Name() {
+	case "kicad_pcb":
+		var footprints []Footprint
+		for _, n := range root.Nodes {
+			if name := n.Name(); name != "footprint" && name != "module" {
+				continue
+			}
+
+			fp, err := readFootprint(n, true)
+			if err != nil {
+				return nil, err
+			}
+			footprints = append(footprints, fp)
+		}
+
+		return footprints, nil
+	case "footprint", "module":
+		fp, err := readFootprint(root, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return []Footprint{fp}, nil
+	default:
+		return nil, fmt.Errorf("not a KiCad board or footprint file: %q", root.Name())
+	}
+}
+
+func readFootprint(n *sexpr.Node, onBoard bool) (Footprint, error) {
+	fp := Footprint{Side: "top"}
+
+	if library, name, ok := strings.Cut(n.Arg(0), ":"); ok {
+		fp.Library, fp.Name = library, name
+	} else {
+		fp.Name = n.Arg(0)
+	}
+
+	if strings.HasPrefix(n.Find("layer").Arg(0), "B.") {
+		fp.Side = "bottom"
+	}
+
+	// Board files store the absolute rotation of pads.
+	var rotation float64
+	if at := n.Find("at"); onBoard && at != nil && at.Arg(2) != "" {
+		var err error
+		if rotation, err = at.Float(2); err != nil {
+			return fp, fmt.Errorf("footprint %s: %w", fp.Name, err)
+		}
+	}
+
+	// Flipped footprints are mirrored top to bottom (in footprint coordinates).
+	mirror := 1.0
+	if onBoard && fp.Side == "bottom" {
+		mirror = -1
+	}
+
+	for _, child := range n.Nodes {
+		switch child.Name() {
+		case "property":
+			switch child.Arg(0) {
+			case "Reference":
+				fp.Ref = child.Arg(1)
+			case "Value":
+				fp.Value = child.Arg(1)
+			}
+		case "fp_text":
+			switch child.Arg(0) {
+			case "reference":
+				fp.Ref = child.Arg(1)
+			case "value":
+				fp.Value = child.Arg(1)
+			}
+		case "pad":
+			pad, err := readPad(child)
+			if err != nil {
+				return fp, fmt.Errorf("footprint %s: %w", fp.Name, err)
+			}
+
+			pad.Y *= mirror
+			pad.Rotation = normalizeAngle(pad.Rotation - rotation)
+
+			fp.Pads = append(fp.Pads, pad)
+		case "fp_line", "fp_rect", "fp_poly", "fp_circle", "fp_arc":
+			bounds, ok, err := graphicBounds(child)
+			if err != nil {
+				return fp, fmt.Errorf("footprint %s: %w", fp.Name, err)
+			}
+			if !ok {
+				continue
+			}
+
+			if mirror < 0 {
+				bounds.MinY, bounds.MaxY = -bounds.MaxY, -bounds.MinY
+			}
+
+			switch layer := child.Find("layer").Arg(0); layer {
+			case "F.CrtYd", "B.CrtYd":
+				fp.Courtyard = union(fp.Courtyard, bounds)
+			case "F.Fab", "B.Fab":
+				fp.Fab = union(fp.Fab, bounds)
+			}
+		}
+	}
+
+	return fp, nil
+}
+
+func readPad(n *sexpr.Node) (Pad, error) {
+	pad := Pad{Number: n.Arg(0)}
+
+	at := n.Find("at")
+	if at == nil {
+		return pad, fmt.Errorf("pad %s has no position", pad.Number)
+	}
+
+	var err error
+	if pad.X, err = at.Float(0); err != nil {
+		return pad, fmt.Errorf("pad %s: %w", pad.Number, err)
+	}
+	if pad.Y, err = at.Float(1); err != nil {
+		return pad, fmt.Errorf("pad %s: %w", pad.Number, err)
+	}
+	if at.Arg(2) != "" {
+		if pad.Rotation, err = at.Float(2); err != nil {
+			return pad, fmt.Errorf("pad %s: %w", pad.Number, err)
+		}
+	}
+
+	if size := n.Find("size"); size != nil {
+		if pad.Width, err = size.Float(0); err != nil {
+			return pad, fmt.Errorf("pad %s: %w", pad.Number, err)
+		}
+		if pad.Height, err = size.Float(1); err != nil {
+			return pad, fmt.Errorf("pad %s: %w", pad.Number, err)
+		}
+	}
+
+	return pad, nil
+}
+
+// graphicBounds returns the bounding box of a graphic item (arcs are
+// approximated by their end and mid points).
+func graphicBounds(n *sexpr.Node) (Rect, bool, error) {
+	var points [][2]float64
+	for _, name := range []string{"start", "mid", "end", "center"} {
+		if p := n.Find(name); p != nil {
+			point, err := readPoint(p)
+			if err != nil {
+				return Rect{}, false, err
+			}
+			points = append(points, point)
+		}
+	}
+
+	if pts := n.Find("pts"); pts != nil {
+		for _, xy := range pts.FindAll("xy") {
+			point, err := readPoint(xy)
+			if err != nil {
+				return Rect{}, false, err
+			}
+			points = append(points, point)
+		}
+	}
+
+	if len(points) == 0 {
+		return Rect{}, false, nil
+	}
+
+	bounds := Rect{MinX: points[0][0], MinY: points[0][1], MaxX: points[0][0], MaxY: points[0][1]}
+	for _, p := range points[1:] {
+		bounds = bounds.Union(Rect{MinX: p[0], MinY: p[1], MaxX: p[0], MaxY: p[1]})
+	}
+
+	if n.Name() == "fp_circle" && n.Find("center") != nil && n.Find("end") != nil {
+		center, _ := readPoint(n.Find("center"))
+		end, _ := readPoint(n.Find("end"))
+		radius := math.Hypot(end[0]-center[0], end[1]-center[1])
+
+		bounds = Rect{
+			MinX: center[0] - radius,
+			MinY: center[1] - radius,
+			MaxX: center[0] + radius,
+			MaxY: center[1] + radius,
+		}
+	}
+
+	return bounds, true, nil
+}
+
+func readPoint(n *sexpr.Node) ([2]float64, error) {
+	x, err := n.Float(0)
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	y, err := n.Float(1)
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	return [2]float64{x, y}, nil
+}
+
+func union(r *Rect, other Rect) *Rect {
+	if r == nil {
+		return &other
+	}
+
+	u := r.Union(other)
+	return &u
+}
+
+// normalizeAngle normalizes an angle to [0, 360).
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+
+	return angle
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package footprint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/footprint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFootprint(t *testing.T) {
+	footprints, err := footprint.Load("testdata/SOIC-8_3.9x4.9mm_P1.27mm.kicad_mod")
+	require.NoError(t, err)
+	require.Len(t, footprints, 1)
+
+	fp := footprints[0]
+	assert.Equal(t, "SOIC-8_3.9x4.9mm_P1.27mm", fp.Name)
+	assert.Equal(t, "", fp.Library)
+	assert.Equal(t, "REF**", fp.Ref)
+	assert.Equal(t, "top", fp.Side)
+
+	require.Len(t, fp.Pads, 8)
+	assert.Equal(t, footprint.Pad{Number: "1", X: -2.475, Y: -1.905, Width: 1.95, Height: 0.6}, fp.Pads[0])
+
+	require.NotNil(t, fp.Courtyard)
+	assert.Equal(t, footprint.Rect{MinX: -3.7, MinY: -2.7, MaxX: 3.7, MaxY: 2.7}, *fp.Courtyard)
+
+	require.NotNil(t, fp.Fab)
+	assert.Equal(t, footprint.Rect{MinX: -1.95, MinY: -2.45, MaxX: 1.95, MaxY: 2.45}, *fp.Fab)
+
+	bounds, ok := fp.PadBounds()
+	require.True(t, ok)
+	assert.InDelta(t, -3.45, bounds.MinX, 1e-9)
+	assert.InDelta(t, 3.45, bounds.MaxX, 1e-9)
+	assert.InDelta(t, -2.205, bounds.MinY, 1e-9)
+	assert.InDelta(t, 2.205, bounds.MaxY, 1e-9)
+}
+
+func TestLoadBoard(t *testing.T) {
+	footprints, err := footprint.Load("testdata/board.kicad_pcb")
+	require.NoError(t, err)
+	require.Len(t, footprints, 3)
+
+	soic := footprints[0]
+	assert.Equal(t, "Package_SO", soic.Library)
+	assert.Equal(t, "SOIC-8_3.9x4.9mm_P1.27mm", soic.Name)
+	assert.Equal(t, "U1", soic.Ref)
+	assert.Equal(t, "LM358", soic.Value)
+	require.Len(t, soic.Pads, 8)
+	// Pad rotations are made relative to the footprint.
+	assert.Equal(t, 0.0, soic.Pads[0].Rotation)
+
+	assert.Equal(t, "R1", footprints[1].Ref)
+	require.NotNil(t, footprints[1].Courtyard)
+	assert.Equal(t, footprint.Rect{MinX: -1.48, MinY: -0.73, MaxX: 1.48, MaxY: 0.73}, *footprints[1].Courtyard)
+
+	// Footprints on the back are mirrored back to match the library footprint.
+	header := footprints[2]
+	assert.Equal(t, "J1", header.Ref)
+	assert.Equal(t, "bottom", header.Side)
+	require.Len(t, header.Pads, 3)
+	assert.Equal(t, 2.54, header.Pads[1].Y)
+	assert.Equal(t, 5.08, header.Pads[2].Y)
+	require.NotNil(t, header.Courtyard)
+	assert.Equal(t, footprint.Rect{MinX: -1.8, MinY: -1.8, MaxX: 1.8, MaxY: 6.85}, *header.Courtyard)
+}
+
+func TestReadLegacyModule(t *testing.T) {
+	footprints, err := footprint.Read(strings.NewReader(`(module Diode_SMD:D_SMA (layer F.Cu) (tedit 5B24D78E)
+  (fp_text reference D1 (at 0 -2.5) (layer F.SilkS))
+  (fp_text value SS14 (at 0 2.6) (layer F.Fab))
+  (fp_circle (center 0 0) (end 1 0) (layer F.Fab) (width 0.1))
+  (pad 1 smd rect (at -2 0) (size 2.5 1.8) (layers F.Cu F.Paste F.Mask))
+  (pad 2 smd rect (at 2 0) (size 2.5 1.8) (layers F.Cu F.Paste F.Mask))
+)`))
+	require.NoError(t, err)
+	require.Len(t, footprints, 1)
+
+	fp := footprints[0]
+	assert.Equal(t, "D_SMA", fp.Name)
+	assert.Equal(t, "D1", fp.Ref)
+	assert.Equal(t, "SS14", fp.Value)
+	require.Len(t, fp.Pads, 2)
+	assert.Equal(t, "2", fp.Pads[1].Number)
+	assert.Nil(t, fp.Courtyard)
+	require.NotNil(t, fp.Fab)
+	assert.Equal(t, footprint.Rect{MinX: -1, MinY: -1, MaxX: 1, MaxY: 1}, *fp.Fab)
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := footprint.Read(strings.NewReader(`(kicad_sch (version 20231120))`))
+	assert.Error(t, err)
+
+	_, err = footprint.Read(strings.NewReader(`(footprint "R_0603" (pad "1" smd rect (size 1 1)))`))
+	assert.Error(t, err)
+}
+
+func TestPadBoundsRotated(t *testing.T) {
+	pad := footprint.Pad{X: 1, Y: 2, Width: 2, Height: 1, Rotation: 90}
+
+	bounds := pad.Bounds()
+	assert.InDelta(t, 0.5, bounds.MinX, 1e-9)
+	assert.InDelta(t, 1.5, bounds.MaxX, 1e-9)
+	assert.InDelta(t, 1, bounds.MinY, 1e-9)
+	assert.InDelta(t, 3, bounds.MaxY, 1e-9)
+
+	x, y := bounds.Center()
+	assert.InDelta(t, 1, x, 1e-9)
+	assert.InDelta(t, 2, y, 1e-9)
+}
+
+func TestCentroid(t *testing.T) {
+	footprints, err := footprint.Load("testdata/PinHeader_1x03_P2.54mm_Vertical.kicad_mod")
+	require.NoError(t, err)
+	require.Len(t, footprints, 1)
+
+	x, y, source, ok := footprints[0].Centroid()
+	require.True(t, ok)
+	assert.Equal(t, "courtyard", source)
+	assert.InDelta(t, 0, x, 1e-9)
+	assert.InDelta(t, 2.525, y, 1e-9)
+
+	// Without a courtyard the pads are used.
+	fp := footprints[0]
+	fp.Courtyard = nil
+
+	x, y, source, ok = fp.Centroid()
+	require.True(t, ok)
+	assert.Equal(t, "pads", source)
+	assert.InDelta(t, 0, x, 1e-9)
+	assert.InDelta(t, 2.54, y, 1e-9)
+
+	_, _, _, ok = footprint.Footprint{Name: "Empty"}.Centroid()
+	assert.False(t, ok)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package placement
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+)
+
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeMoved   = "moved"
+)
+
+// Tolerance is how far a placement may move before it is considered changed.
+type Tolerance struct {
+	// Position is the maximum distance (in mm).
+	Position float64
+	// Rotation is the maximum difference in rotation (in degrees).
+	Rotation float64
+}
+
+// PlacementChange describes how a component placement changed between two
+// revisions of a board.
+type PlacementChange struct {
+	Ref string `json:"ref" yaml:"ref"`
+	// Change is one of "added", "removed" or "moved".
+	Change string     `json:"change" yaml:"change"`
+	Old    *Placement `json:"old,omitempty" yaml:"old,omitempty"`
+	New    *Placement `json:"new,omitempty" yaml:"new,omitempty"`
+	// Distance is how far the component moved (in mm).
+	Distance float64 `json:"distance,omitempty" yaml:"distance,omitempty"`
+	// Rotation is the change in rotation (in degrees, between -180 and 180).
+	Rotation float64 `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+	// Flipped is set if the component moved to the other side of the board.
+	Flipped bool `json:"flipped,omitempty" yaml:"flipped,omitempty"`
+}
+
+// Diff compares two revisions of component placements, reporting components
+// that were added, removed, or that moved beyond the given tolerance.
+func Diff(old, new []Placement, tol Tolerance) []PlacementChange {
+	oldPlacements := placementsByRef(old)
+	newPlacements := placementsByRef(new)
+
+	var changes []PlacementChange
+
+	for ref, oldPlacement := range oldPlacements {
+		newPlacement, ok := newPlacements[ref]
+		if !ok {
+			changes = append(changes, PlacementChange{
+				Ref:    ref,
+				Change: ChangeRemoved,
+				Old:    &oldPlacement,
+			})
+			continue
+		}
+
+		distance := math.Hypot(newPlacement.PosX-oldPlacement.PosX, newPlacement.PosY-oldPlacement.PosY)
+		rotation := angleDifference(oldPlacement.Rot, newPlacement.Rot)
+		flipped := oldPlacement.Side != newPlacement.Side
+
+		if distance > tol.Position || math.Abs(rotation) > tol.Rotation || flipped {
+			changes = append(changes, PlacementChange{
+				Ref:      ref,
+				Change:   ChangeMoved,
+				Old:      &oldPlacement,
+				New:      &newPlacement,
+				Distance: distance,
+				Rotation: rotation,
+				Flipped:  flipped,
+			})
+		}
+	}
+
+	for ref, newPlacement := range newPlacements {
+		if _, ok := oldPlacements[ref]; !ok {
+			changes = append(changes, PlacementChange{
+				Ref:    ref,
+				Change: ChangeAdded,
+				New:    &newPlacement,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return bom.NaturalLess(changes[i].Ref, changes[j].Ref)
+	})
+
+	return changes
+}
+
+func placementsByRef(placements []Placement) map[string]Placement {
+	byRef := make(map[string]Placement, len(placements))
+	for _, p := range placements {
+		byRef[p.Ref] = p
+	}
+	return byRef
+}
+
+// angleDifference returns the signed difference between two angles, normalized
+// to (-180, 180].
+func angleDifference(from, to float64) float64 {
+	diff := math.Mod(to-from, 360)
+	if diff > 180 {
+		diff -= 360
+	} else if diff <= -180 {
+		diff += 360
+	}
+	return diff
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package placement_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	changes := placement.Diff([]placement.Placement{
+		{Ref: "C1", PosX: 10, PosY: 10, Rot: 0, Side: "top"},
+		{Ref: "C2", PosX: 20, PosY: 10, Rot: 350, Side: "top"},
+		{Ref: "R1", PosX: 30, PosY: 10, Rot: 90, Side: "top"},
+		{Ref: "U1", PosX: 40, PosY: 10, Rot: 0, Side: "top"},
+	}, []placement.Placement{
+		{Ref: "C1", PosX: 10.005, PosY: 10, Rot: 0, Side: "top"},
+		{Ref: "C2", PosX: 20, PosY: 10, Rot: 10, Side: "top"},
+		{Ref: "R1", PosX: 33, PosY: 14, Rot: 90, Side: "top"},
+		{Ref: "R2", PosX: 50, PosY: 10, Rot: 0, Side: "bottom"},
+	}, placement.Tolerance{Position: 0.01, Rotation: 0.1})
+
+	require.Len(t, changes, 4)
+
+	assert.Equal(t, "C2", changes[0].Ref)
+	assert.Equal(t, placement.ChangeMoved, changes[0].Change)
+	assert.InDelta(t, 20.0, changes[0].Rotation, 0.000001)
+	assert.InDelta(t, 0.0, changes[0].Distance, 0.000001)
+
+	assert.Equal(t, "R1", changes[1].Ref)
+	assert.Equal(t, placement.ChangeMoved, changes[1].Change)
+	assert.InDelta(t, 5.0, changes[1].Distance, 0.000001)
+
+	assert.Equal(t, "R2", changes[2].Ref)
+	assert.Equal(t, placement.ChangeAdded, changes[2].Change)
+
+	assert.Equal(t, "U1", changes[3].Ref)
+	assert.Equal(t, placement.ChangeRemoved, changes[3].Change)
+}
+
+func TestDiffNaturalOrder(t *testing.T) {
+	changes := placement.Diff(nil, []placement.Placement{
+		{Ref: "R10"},
+		{Ref: "R2"},
+		{Ref: "C1"},
+		{Ref: "R1"},
+	}, placement.Tolerance{})
+
+	var refs []string
+	for _, change := range changes {
+		refs = append(refs, change.Ref)
+	}
+
+	assert.Equal(t, []string{"C1", "R1", "R2", "R10"}, refs)
+}
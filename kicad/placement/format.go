@@ -0,0 +1,125 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package placement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/internal/registry"
+)
+
+// sniffLen is how much of a file is passed to Reader.Sniff.
+const sniffLen = registry.SniffLen
+
+// Reader reads component placements from a particular EDA tool's output.
+// Readers for additional formats can be registered with RegisterReader, eg.
+//
+//	func init() {
+//		placement.RegisterReader(&myReader{})
+//	}
+type Reader interface {
+	// Name is the short name of the format (eg. "kicad").
+	Name() string
+	// Sniff reports whether a file is in this format, based on its name and
+	// the first few kilobytes of its content.
+	Sniff(name string, head []byte) bool
+	// Read reads all the component placements from r.
+	Read(r io.Reader) ([]Placement, error)
+}
+
+var readers = registry.New[Placement]("component placement")
+
+func init() {
+	RegisterReader(&kicadReader{})
+}
+
+// RegisterReader registers a component placement reader. Readers are sniffed
+// in the order they were registered. It panics if a reader with the same name
+// is already registered.
+func RegisterReader(r Reader) {
+	if err := readers.Register(r); err != nil {
+		panic(err)
+	}
+}
+
+// Readers returns all the registered component placement readers.
+func Readers() []Reader {
+	registered := readers.Readers()
+
+	rs := make([]Reader, 0, len(registered))
+	for _, r := range registered {
+		rs = append(rs, r)
+	}
+
+	return rs
+}
+
+// LookupReader finds a registered component placement reader by name.
+func LookupReader(name string) (Reader, bool) {
+	return readers.Lookup(name)
+}
+
+// Load loads component placements from a file. If format is empty, it will be
+// detected from the file's name and content. The name of the format is returned
+// alongside the placements.
+func Load(path, format string) ([]Placement, string, error) {
+	return readers.Load(path, format)
+}
+
+// kicadReader reads KiCad component placement files (either CSV or ASCII .pos).
+type kicadReader struct{}
+
+func (r *kicadReader) Name() string {
+	return "kicad"
+}
+
+func (r *kicadReader) Sniff(name string, head []byte) bool {
+	text := csvx.DecodeText(head)
+	if strings.EqualFold(filepath.Ext(name), ".pos") || isPOS(text) {
+		return true
+	}
+
+	header, _, _ := bytes.Cut(text, []byte("\n"))
+	return strings.Contains(string(header), "Ref") && strings.Contains(string(header), "PosX")
+}
+
+func (r *kicadReader) Read(rd io.Reader) ([]Placement, error) {
+	br := bufio.NewReaderSize(rd, sniffLen)
+
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	if isPOS(csvx.DecodeText(head)) {
+		return ReadPOS(br)
+	}
+
+	placements, err := csvx.Unmarshal[Placement](br)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+
+	return placements, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package placement_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReader struct{}
+
+func (r *testReader) Name() string {
+	return "test"
+}
+
+func (r *testReader) Sniff(name string, head []byte) bool {
+	return bytes.HasPrefix(head, []byte("TEST"))
+}
+
+func (r *testReader) Read(rd io.Reader) ([]placement.Placement, error) {
+	return []placement.Placement{{Ref: "T1"}}, nil
+}
+
+func init() {
+	placement.RegisterReader(&testReader{})
+}
+
+func TestLoad(t *testing.T) {
+	placements, format, err := placement.Load("testdata/placements.csv", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "kicad", format)
+	assert.Len(t, placements, 6)
+}
+
+func TestLoadPOS(t *testing.T) {
+	placements, format, err := placement.Load("testdata/board-all.pos", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "kicad", format)
+	assert.Len(t, placements, 6)
+}
+
+func TestLoadExcelDialect(t *testing.T) {
+	expected, _, err := placement.Load("testdata/placements.csv", "")
+	require.NoError(t, err)
+
+	// Re-saved by a spreadsheet in a European locale.
+	placements, format, err := placement.Load("testdata/placements-excel.csv", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "kicad", format)
+	assert.Equal(t, expected, placements)
+}
+
+func TestLoadRegisteredReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.test")
+	require.NoError(t, os.WriteFile(path, []byte("TEST\n"), 0o644))
+
+	placements, format, err := placement.Load(path, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", format)
+	assert.Equal(t, []placement.Placement{{Ref: "T1"}}, placements)
+
+	// Explicitly selecting a format skips sniffing.
+	_, format, err = placement.Load("testdata/placements.csv", "test")
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", format)
+}
+
+func TestLoadUnrecognized(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world\n"), 0o644))
+
+	_, _, err := placement.Load(path, "")
+	require.Error(t, err)
+
+	_, _, err = placement.Load("testdata/placements.csv", "unknown")
+	require.Error(t, err)
+}
+
+func TestRegisterReaderDuplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		placement.RegisterReader(&testReader{})
+	})
+}
@@ -26,13 +26,13 @@ import (
 
 // Placement represents a component placement.
 type Placement struct {
-	Ref     string  `csv:"Ref"`
-	Val     string  `csv:"Val"`
-	Package string  `csv:"Package"`
-	PosX    float64 `csv:"PosX"`
-	PosY    float64 `csv:"PosY"`
-	Rot     float64 `csv:"Rot"`
-	Side    string  `csv:"Side"`
+	Ref     string  `csv:"Ref" json:"ref" yaml:"ref"`
+	Val     string  `csv:"Val" json:"val" yaml:"val"`
+	Package string  `csv:"Package" json:"package" yaml:"package"`
+	PosX    float64 `csv:"PosX" json:"pos_x" yaml:"pos_x"`
+	PosY    float64 `csv:"PosY" json:"pos_y" yaml:"pos_y"`
+	Rot     float64 `csv:"Rot" json:"rot" yaml:"rot"`
+	Side    string  `csv:"Side" json:"side" yaml:"side"`
 }
 
 // LoadFromCSV loads KiCad component placements from a CSV file.
@@ -50,3 +50,35 @@ func LoadFromCSV(path string) ([]Placement, error) {
 
 	return placements, nil
 }
+
+// Exclude removes the placements of the given designators (eg. parts marked
+// DNP in the BOM).
+func Exclude(placements []Placement, refs []string) (kept, excluded []Placement) {
+	exclude := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		exclude[ref] = true
+	}
+
+	for _, p := range placements {
+		if exclude[p.Ref] {
+			excluded = append(excluded, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+
+	return kept, excluded
+}
+
+// Translate moves component placements by (dx, dy) millimetres, eg. to make
+// them relative to a different origin.
+func Translate(placements []Placement, dx, dy float64) []Placement {
+	translated := make([]Placement, 0, len(placements))
+	for _, p := range placements {
+		p.PosX += dx
+		p.PosY += dy
+		translated = append(translated, p)
+	}
+
+	return translated
+}
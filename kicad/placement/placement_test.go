@@ -49,3 +49,28 @@ func TestLoadFromCSV(t *testing.T) {
 	assert.Equal(t, 90.0, placements[5].Rot)
 	assert.Equal(t, "top", placements[5].Side)
 }
+
+func TestExclude(t *testing.T) {
+	kept, excluded := placement.Exclude([]placement.Placement{
+		{Ref: "C1"},
+		{Ref: "R1"},
+		{Ref: "R2"},
+	}, []string{"R1", "U1"})
+
+	require.Len(t, kept, 2)
+	assert.Equal(t, "C1", kept[0].Ref)
+	assert.Equal(t, "R2", kept[1].Ref)
+
+	require.Len(t, excluded, 1)
+	assert.Equal(t, "R1", excluded[0].Ref)
+}
+
+func TestTranslate(t *testing.T) {
+	placements := []placement.Placement{{Ref: "C1", PosX: 10, PosY: -20}}
+
+	translated := placement.Translate(placements, -5, 2.5)
+	assert.Equal(t, []placement.Placement{{Ref: "C1", PosX: 5, PosY: -17.5}}, translated)
+
+	// The original placements are unchanged.
+	assert.Equal(t, 10.0, placements[0].PosX)
+}
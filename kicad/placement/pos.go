@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package placement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+)
+
+// posUnits are the supported units of ASCII .pos files (in millimetres).
+var posUnits = map[string]float64{
+	"mm":     1,
+	"in":     25.4,
+	"inch":   25.4,
+	"inches": 25.4,
+}
+
+// posHeader matches the (commented out) header row of an ASCII .pos file.
+var posHeader = regexp.MustCompile(`(?m)^#\s*Ref\s+Val\s+Package\s`)
+
+// isPOS reports whether the start of a file looks like an ASCII .pos file.
+func isPOS(head []byte) bool {
+	return bytes.Contains(head, []byte("## Unit =")) || posHeader.Match(head)
+}
+
+// LoadFromPOS loads KiCad component placements from an ASCII .pos file.
+func LoadFromPOS(path string) ([]Placement, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	return ReadPOS(f)
+}
+
+// ReadPOS reads KiCad component placements from an ASCII .pos file (the
+// whitespace aligned format written by "Fabrication Outputs > Component
+// Placement"). Coordinates are converted to millimetres. If the rows don't have
+// a side, it's taken from the "## Side" line of the preamble (as written for
+// separate front and back files).
+func ReadPOS(r io.Reader) ([]Placement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %w", err)
+	}
+
+	scale, side, err := readPOSPreamble(csvx.DecodeText(data))
+	if err != nil {
+		return nil, err
+	}
+
+	dec := csvx.NewDecoder[Placement](bytes.NewReader(data),
+		csvx.WithDelimiter(csvx.Whitespace),
+		csvx.WithDecimalSeparator('.'),
+		csvx.WithComment('#'),
+		csvx.WithHeader(func(record []string) bool {
+			return len(record) > 0 && record[0] == "Ref"
+		}))
+
+	var placements []Placement
+	for p, err := range dec.All() {
+		if err != nil {
+			return nil, fmt.Errorf("could not parse placements: %w", err)
+		}
+
+		p.PosX *= scale
+		p.PosY *= scale
+
+		if p.Side == "" {
+			p.Side = side
+		}
+		p.Side = normalizeSide(p.Side)
+
+		placements = append(placements, p)
+	}
+
+	return placements, nil
+}
+
+// readPOSPreamble reads the units and side from the preamble of an ASCII .pos
+// file, eg.
+//
+//	## Unit = mm, Angle = deg.
+//	## Side : top
+func readPOSPreamble(data []byte) (float64, string, error) {
+	scale := 1.0
+	var side string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+
+		line = strings.TrimSpace(strings.TrimLeft(line, "#"))
+
+		if value, ok := strings.CutPrefix(line, "Side"); ok {
+			value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), ":"))
+			if !strings.EqualFold(value, "all") {
+				side = value
+			}
+			continue
+		}
+
+		for _, setting := range strings.Split(line, ",") {
+			key, value, ok := strings.Cut(setting, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "Unit") {
+				continue
+			}
+
+			units := strings.ToLower(strings.TrimSpace(value))
+			if scale, ok = posUnits[units]; !ok {
+				return 0, "", fmt.Errorf("unsupported units: %s", units)
+			}
+		}
+	}
+
+	return scale, side, nil
+}
+
+// normalizeSide converts KiCad's side names into "top" or "bottom".
+func normalizeSide(side string) string {
+	switch strings.ToLower(side) {
+	case "top", "front", "f.cu":
+		return "top"
+	case "bottom", "back", "b.cu":
+		return "bottom"
+	default:
+		return side
+	}
+}
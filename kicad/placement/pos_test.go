@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package placement_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFromPOS(t *testing.T) {
+	expected, err := placement.LoadFromCSV("testdata/placements.csv")
+	require.NoError(t, err)
+
+	placements, err := placement.LoadFromPOS("testdata/board-all.pos")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, placements)
+}
+
+func TestLoadFromPOSInches(t *testing.T) {
+	placements, err := placement.LoadFromPOS("testdata/board-bottom.pos")
+	require.NoError(t, err)
+	require.Len(t, placements, 2)
+
+	assert.Equal(t, "C1", placements[0].Ref)
+	assert.InDelta(t, 28.194, placements[0].PosX, 1e-6)
+	assert.InDelta(t, -173.26, placements[0].PosY, 1e-4)
+	assert.Equal(t, "bottom", placements[0].Side)
+
+	assert.Equal(t, 90.0, placements[1].Rot)
+}
+
+func TestReadPOSSideFromPreamble(t *testing.T) {
+	placements, err := placement.ReadPOS(strings.NewReader(`### Module positions - created on 2019-05-01 ###
+### Printed by Pcbnew version 5.1.2
+## Unit = mm, Angle = deg.
+## Side : back
+# Ref    Val     Package              PosX     PosY    Rot
+R1       10k     R_0603_1608Metric   10.0000  -5.0000  180.0000
+## End
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []placement.Placement{{
+		Ref:     "R1",
+		Val:     "10k",
+		Package: "R_0603_1608Metric",
+		PosX:    10,
+		PosY:    -5,
+		Rot:     180,
+		Side:    "bottom",
+	}}, placements)
+}
+
+func TestReadPOSUnsupportedUnits(t *testing.T) {
+	_, err := placement.ReadPOS(strings.NewReader("## Unit = furlongs, Angle = deg.\n# Ref Val Package PosX PosY Rot Side\n"))
+	assert.Error(t, err)
+}
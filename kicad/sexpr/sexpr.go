@@ -0,0 +1,249 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package sexpr parses the S-expressions used by KiCad's board and footprint
+// files (eg. "(footprint "R_0603" (layer "F.Cu") (at 10 20 90))").
+package sexpr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Node is an S-expression, either an atom (a symbol, number or string) or a
+// list of nodes.
+type Node struct {
+	// Value is the (unquoted) value of an atom.
+	Value string
+	// Nodes are the elements of a list.
+	Nodes []*Node
+	list  bool
+}
+
+// Parse parses a single S-expression (eg. a whole .kicad_pcb file).
+func Parse(r io.Reader) (*Node, error) {
+	p := &parser{r: bufio.NewReader(r), line: 1}
+
+	n, err := p.parse()
+	if err == io.EOF {
+		return nil, errors.New("could not parse S-expression: empty input")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse S-expression: line %d: %w", p.line, err)
+	}
+
+	return n, nil
+}
+
+// IsList reports whether the node is a list.
+func (n *Node) IsList() bool {
+	return n != nil && n.list
+}
+
+// Name returns the name of a list, ie. its first atom (eg. "footprint").
+func (n *Node) Name() string {
+	if !n.IsList() || len(n.Nodes) == 0 || n.Nodes[0].list {
+		return ""
+	}
+
+	return n.Nodes[0].Value
+}
+
+// Arg returns the value of the i'th atom after the name of a list (or an empty
+// string if there isn't one).
+func (n *Node) Arg(i int) string {
+	if !n.IsList() || i+1 >= len(n.Nodes) || n.Nodes[i+1].list {
+		return ""
+	}
+
+	return n.Nodes[i+1].Value
+}
+
+// Float returns the i'th argument of a list as a number.
+func (n *Node) Float(i int) (float64, error) {
+	v, err := strconv.ParseFloat(n.Arg(i), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number in %s: %w", n.Name(), err)
+	}
+
+	return v, nil
+}
+
+// Find returns the first child list with a name (or nil).
+func (n *Node) Find(name string) *Node {
+	if !n.IsList() {
+		return nil
+	}
+
+	for _, child := range n.Nodes {
+		if child.Name() == name {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// FindAll returns the child lists with a name.
+func (n *Node) FindAll(name string) []*Node {
+	if !n.IsList() {
+		return nil
+	}
+
+	var children []*Node
+	for _, child := range n.Nodes {
+		if child.Name() == name {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+type parser struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (p *parser) parse() (*Node, error) {
+	c, err := p.skipSpace()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c {
+	case '(':
+		n := &Node{list: true}
+		for {
+			c, err := p.skipSpace()
+			if err == io.EOF {
+				return nil, errors.New("unexpected end of input (unbalanced parentheses)")
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if c == ')' {
+				return n, nil
+			}
+
+			if err := p.r.UnreadByte(); err != nil {
+				return nil, err
+			}
+
+			child, err := p.parse()
+			if err == io.EOF {
+				return nil, errors.New("unexpected end of input (unbalanced parentheses)")
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			n.Nodes = append(n.Nodes, child)
+		}
+	case ')':
+		return nil, errors.New("unexpected ')'")
+	case '"':
+		return p.parseString()
+	default:
+		var sb strings.Builder
+		sb.WriteByte(c)
+		for {
+			c, err := p.r.ReadByte()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if c == '(' || c == ')' || isSpace(c) {
+				if err := p.r.UnreadByte(); err != nil {
+					return nil, err
+				}
+				break
+			}
+
+			sb.WriteByte(c)
+		}
+
+		return &Node{Value: sb.String()}, nil
+	}
+}
+
+func (p *parser) parseString() (*Node, error) {
+	var sb strings.Builder
+	for {
+		c, err := p.r.ReadByte()
+		if err == io.EOF {
+			return nil, errors.New("unterminated string")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch c {
+		case '"':
+			return &Node{Value: sb.String()}, nil
+		case '\\':
+			c, err = p.r.ReadByte()
+			if err == io.EOF {
+				return nil, errors.New("unterminated string")
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			switch c {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			}
+		case '\n':
+			p.line++
+		}
+
+		sb.WriteByte(c)
+	}
+}
+
+// skipSpace returns the next byte that isn't whitespace.
+func (p *parser) skipSpace() (byte, error) {
+	for {
+		c, err := p.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		if c == '\n' {
+			p.line++
+		}
+
+		if !isSpace(c) {
+			return c, nil
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sexpr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/sexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	n, err := sexpr.Parse(strings.NewReader(`(footprint "Package_SO:SOIC-8 \"narrow\""
+	(layer "F.Cu")
+	(at 100.5 -20 90)
+	(pad "1" smd roundrect (at -2.475 -1.905) (size 1.95 0.6))
+	(pad "2" smd roundrect (at -2.475 -0.635) (size 1.95 0.6))
+)`))
+	require.NoError(t, err)
+
+	assert.True(t, n.IsList())
+	assert.Equal(t, "footprint", n.Name())
+	assert.Equal(t, `Package_SO:SOIC-8 "narrow"`, n.Arg(0))
+	assert.Equal(t, "", n.Arg(1))
+
+	assert.Equal(t, "F.Cu", n.Find("layer").Arg(0))
+
+	at := n.Find("at")
+	require.NotNil(t, at)
+	x, err := at.Float(0)
+	require.NoError(t, err)
+	assert.Equal(t, 100.5, x)
+	y, err := at.Float(1)
+	require.NoError(t, err)
+	assert.Equal(t, -20.0, y)
+
+	_, err = at.Float(3)
+	assert.Error(t, err)
+
+	pads := n.FindAll("pad")
+	require.Len(t, pads, 2)
+	assert.Equal(t, "1", pads[0].Arg(0))
+	assert.Equal(t, "smd", pads[0].Arg(1))
+	assert.Equal(t, "roundrect", pads[0].Arg(2))
+	assert.Equal(t, "", pads[0].Arg(3))
+	assert.Equal(t, "2", pads[1].Arg(0))
+
+	assert.Nil(t, n.Find("model"))
+	assert.Nil(t, pads[0].Nodes[1].Find("at"))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"(footprint (layer F.Cu)",
+		")",
+		`(footprint "SOIC-8`,
+	} {
+		_, err := sexpr.Parse(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
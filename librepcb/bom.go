@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package librepcb
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+)
+
+func init() {
+	bom.RegisterReader(&bomReader{})
+}
+
+// bomRow is a single row in a LibrePCB BOM. The LCSC part number is read from a
+// custom "LCSC" attribute column.
+type bomRow struct {
+	Quantity    int             `csv:"Quantity"`
+	Designators bom.Designators `csv:"Designators"`
+	Value       string          `csv:"Value"`
+	Package     string          `csv:"Package"`
+	LCSC        string          `csv:"LCSC"`
+}
+
+// ReadBOM reads a LibrePCB BOM.
+func ReadBOM(r io.Reader) ([]bom.Entry, error) {
+	rows, err := csvx.Unmarshal[bomRow](r, csvx.WithComment('#'))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+
+	entries := make([]bom.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, bom.Entry{
+			Reference: row.Designators,
+			Value:     row.Value,
+			Footprint: row.Package,
+			Qty:       row.Quantity,
+			LCSC:      row.LCSC,
+		})
+	}
+
+	return entries, nil
+}
+
+// bomReader reads LibrePCB BOMs.
+type bomReader struct{}
+
+func (r *bomReader) Name() string {
+	return "librepcb"
+}
+
+func (r *bomReader) Sniff(name string, head []byte) bool {
+	return csvx.HasHeader(head, '#', "Quantity", "Designators")
+}
+
+func (r *bomReader) Read(rd io.Reader) ([]bom.Entry, error) {
+	return ReadBOM(rd)
+}
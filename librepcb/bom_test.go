@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package librepcb_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/dpeckett/jlcfabtool/librepcb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBOM(t *testing.T) {
+	f, err := os.Open("testdata/bom.csv")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	entries, err := librepcb.ReadBOM(f)
+	require.NoError(t, err)
+
+	expected := []bom.Entry{
+		{Reference: bom.Designators{"C1", "C2", "C3"}, Value: "100n", Footprint: "C0603", Qty: 3, LCSC: "C14663"},
+		{Reference: bom.Designators{"R1", "R2"}, Value: "10k", Footprint: "R0603", Qty: 2, LCSC: "C25804"},
+	}
+
+	assert.Equal(t, expected, entries)
+}
+
+func TestLoadBOM(t *testing.T) {
+	entries, format, err := bom.Load("testdata/bom.csv", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "librepcb", format)
+	assert.Len(t, entries, 2)
+}
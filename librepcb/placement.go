@@ -0,0 +1,86 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package librepcb
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+)
+
+func init() {
+	placement.RegisterReader(&placementReader{})
+}
+
+// placementRow is a single row in a LibrePCB pick and place file.
+type placementRow struct {
+	Designator string  `csv:"Designator"`
+	Value      string  `csv:"Value"`
+	Package    string  `csv:"Package"`
+	X          float64 `csv:"Position X"`
+	Y          float64 `csv:"Position Y"`
+	Rotation   float64 `csv:"Rotation"`
+	Side       string  `csv:"Side"`
+}
+
+// ReadPlacements reads the component placements from a LibrePCB pick and place
+// file. Both the combined and the per-side files are supported.
+func ReadPlacements(r io.Reader) ([]placement.Placement, error) {
+	rows, err := csvx.Unmarshal[placementRow](r, csvx.WithComment('#'))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+
+	placements := make([]placement.Placement, 0, len(rows))
+	for _, row := range rows {
+		side := strings.ToLower(row.Side)
+		if side != "top" && side != "bottom" {
+			return nil, fmt.Errorf("invalid side for %s: %q", row.Designator, row.Side)
+		}
+
+		placements = append(placements, placement.Placement{
+			Ref:     row.Designator,
+			Val:     row.Value,
+			Package: row.Package,
+			PosX:    row.X,
+			PosY:    row.Y,
+			Rot:     row.Rotation,
+			Side:    side,
+		})
+	}
+
+	return placements, nil
+}
+
+// placementReader reads LibrePCB pick and place files.
+type placementReader struct{}
+
+func (r *placementReader) Name() string {
+	return "librepcb"
+}
+
+func (r *placementReader) Sniff(name string, head []byte) bool {
+	return csvx.HasHeader(head, '#', "Designator", "Position X", "Position Y")
+}
+
+func (r *placementReader) Read(rd io.Reader) ([]placement.Placement, error) {
+	return ReadPlacements(rd)
+}
@@ -18,26 +18,37 @@
 package main
 
 import (
-	_ "embed"
-	"encoding/csv"
 	"fmt"
 	"log/slog"
 	"os"
-	"strconv"
-	"strings"
 
-	"github.com/dpeckett/jlcfabtool/jlcpcb"
-	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	// Register the input format readers.
+	_ "github.com/dpeckett/jlcfabtool/altium"
+	_ "github.com/dpeckett/jlcfabtool/eagle"
+	_ "github.com/dpeckett/jlcfabtool/horizon"
 	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	_ "github.com/dpeckett/jlcfabtool/librepcb"
 	"github.com/urfave/cli/v2"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
+// newInputFormatFlag returns a flag for selecting the input file format.
+func newInputFormatFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (detected from the file if not specified).",
+	}
+}
+
 func main() {
 	app := &cli.App{
 		Name:  "jlcfabtool",
 		Usage: "A little CLI for working with JLCPCB.",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:  "config",
+				Usage: "Project configuration file (defaults to the nearest jlcfabtool.yaml or jlcfabtool.toml).",
+			},
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "bom",
@@ -45,10 +56,140 @@ func main() {
 				Subcommands: []*cli.Command{
 					{
 						Name:      "convert",
-						Usage:     "Convert a KiCad BOM into JLCPCB format.",
+						Usage:     "Convert a BOM into JLCPCB format.",
+						ArgsUsage: "<file>",
+						Flags: []cli.Flag{
+							newInputFormatFlag(),
+							&cli.StringFlag{
+								Name:  "sheet",
+								Usage: "Sheet to read from an xlsx BOM (defaults to the first sheet with a BOM header).",
+							},
+							&cli.PathFlag{
+								Name:  "footprint-map",
+								Usage: "CSV file of additional footprint to JLCPCB package mappings.",
+							},
+							&cli.BoolFlag{
+								Name:  "consolidate",
+								Usage: "Regroup entries by LCSC part number (or value and footprint).",
+							},
+							&cli.StringFlag{
+								Name:  "profile",
+								Usage: profileFlagUsage,
+								Value: "jlcpcb",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: convertFormatFlagUsage,
+								Value: "csv",
+							},
+							&cli.BoolFlag{
+								Name:  "summary",
+								Usage: "Add DNP and warnings sheets (xlsx only).",
+							},
+							&cli.PathFlag{
+								Name:  "prices",
+								Usage: "CSV price list (LCSC Part Number, Unit Price) used to add a cost sheet (xlsx only).",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							profile, err := resolveProfile(c.String("profile"), projectConfig(c).Profiles)
+							if err != nil {
+								return err
+							}
+
+							return convertBOM(c.Args().First(), bomConvertOptions{
+								inputFormat:        c.String("input-format"),
+								sheet:              c.String("sheet"),
+								footprintMapFile:   c.Path("footprint-map"),
+								footprintOverrides: projectConfig(c).Footprints,
+								consolidate:        c.Bool("consolidate"),
+								profile:            profile,
+								format:             c.String("format"),
+								summary:            c.Bool("summary"),
+								policy:             newBOMPolicy(projectConfig(c)),
+								pricesFile:         c.Path("prices"),
+							})
+						},
+					},
+					{
+						Name:      "check",
+						Usage:     "Check a BOM for quantities that disagree with the designators, duplicate designators and unknown DNP values.",
 						ArgsUsage: "<file>",
+						Flags: []cli.Flag{
+							newInputFormatFlag(),
+							&cli.StringFlag{
+								Name:  "sheet",
+								Usage: "Sheet to read from an xlsx BOM (defaults to the first sheet with a BOM header).",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: formatFlagUsage,
+								Value: "csv",
+							},
+							&cli.PathFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Write the entries and warnings to a file instead of stdout.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							return checkBOM(c.Args().First(), bomCheckOptions{
+								inputFormat: c.String("input-format"),
+								sheet:       c.String("sheet"),
+								format:      c.String("format"),
+								output:      c.Path("output"),
+								policy:      newBOMPolicy(projectConfig(c)),
+							})
+						},
+					},
+					{
+						Name:      "merge",
+						Usage:     "Combine the BOMs of several boards into a purchasing summary.",
+						ArgsUsage: "<file>[:qty=<n>]...",
+						Flags: []cli.Flag{
+							newInputFormatFlag(),
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: formatFlagUsage,
+								Value: "csv",
+							},
+							&cli.PathFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Write the summary to a file instead of stdout.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							return mergeBOMs(c.Args().Slice(), bomMergeOptions{
+								inputFormat: c.String("input-format"),
+								format:      c.String("format"),
+								output:      c.Path("output"),
+								policy:      newBOMPolicy(projectConfig(c)),
+							})
+						},
+					},
+					{
+						Name:      "diff",
+						Usage:     "Compare two revisions of a BOM.",
+						ArgsUsage: "<old> <new>",
+						Flags: []cli.Flag{
+							newInputFormatFlag(),
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format (text, json or yaml).",
+								Value: "text",
+							},
+						},
 						Action: func(c *cli.Context) error {
-							return convertKiCadBOM(c.Args().First())
+							if c.NArg() != 2 {
+								return fmt.Errorf("expected two BOM files")
+							}
+
+							return diffBOMs(c.Args().Get(0), c.Args().Get(1), bomDiffOptions{
+								inputFormat: c.String("input-format"),
+								format:      c.String("format"),
+								policy:      newBOMPolicy(projectConfig(c)),
+							})
 						},
 					},
 				},
@@ -59,10 +200,165 @@ func main() {
 				Subcommands: []*cli.Command{
 					{
 						Name:      "convert",
-						Usage:     "Convert a KiCad component placements (CPL) into JLCPCB format.",
-						ArgsUsage: "<file>",
+						Usage:     "Convert component placements (CPL) into JLCPCB format.",
+						ArgsUsage: "<file>...",
+						Flags: []cli.Flag{
+							newInputFormatFlag(),
+							&cli.PathFlag{
+								Name:  "rotations",
+								Usage: "CSV file of additional rotation corrections.",
+							},
+							&cli.PathFlag{
+								Name:  "bom",
+								Usage: "BOM used to exclude the placements of parts marked DNP.",
+							},
+							&cli.PathFlag{
+								Name:  "board",
+								Usage: "KiCad board (.kicad_pcb) used to offset the center of footprints without a rotation correction to the centroid of their pads or courtyard.",
+							},
+							&cli.StringFlag{
+								Name:  "profile",
+								Usage: profileFlagUsage,
+								Value: "jlcpcb",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: convertFormatFlagUsage,
+								Value: "csv",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							profile, err := resolveProfile(c.String("profile"), projectConfig(c).Profiles)
+							if err != nil {
+								return err
+							}
+
+							return convertComponentPlacements(c.Args().Slice(), placementConvertOptions{
+								inputFormat:       c.String("input-format"),
+								rotationsFile:     c.Path("rotations"),
+								rotationOverrides: projectConfig(c).Rotations,
+								bomFile:           c.Path("bom"),
+								boardFile:         c.Path("board"),
+								bomPolicy:         newBOMPolicy(projectConfig(c)),
+								origin:            projectConfig(c).Origin,
+								profile:           profile,
+								format:            c.String("format"),
+							})
+						},
+					},
+					{
+						Name:      "diff",
+						Usage:     "Compare two revisions of component placements (CPL).",
+						ArgsUsage: "<old> <new>",
+						Flags: []cli.Flag{
+							newInputFormatFlag(),
+							&cli.Float64Flag{
+								Name:  "tolerance",
+								Usage: "Ignore position changes smaller than this (in mm).",
+								Value: 0.01,
+							},
+							&cli.Float64Flag{
+								Name:  "rotation-tolerance",
+								Usage: "Ignore rotation changes smaller than this (in degrees).",
+								Value: 0.1,
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format (text, json or yaml).",
+								Value: "text",
+							},
+						},
 						Action: func(c *cli.Context) error {
-							return convertKiCadComponentPlacements(c.Args().First())
+							if c.NArg() != 2 {
+								return fmt.Errorf("expected two component placement files")
+							}
+
+							return diffComponentPlacements(c.Args().Get(0), c.Args().Get(1), placementDiffOptions{
+								inputFormat: c.String("input-format"),
+								tolerance: placement.Tolerance{
+									Position: c.Float64("tolerance"),
+									Rotation: c.Float64("rotation-tolerance"),
+								},
+								format: c.String("format"),
+							})
+						},
+					},
+				},
+			},
+			{
+				Name:  "rotations",
+				Usage: "Commands for working with rotation corrections.",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "lint",
+						Usage:     "Check rotation corrections for invalid, duplicate, shadowed and conflicting rules (defaults to the built-in tables).",
+						ArgsUsage: "[<file>...]",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "input-format",
+								Usage: "Input file format the rotation corrections apply to (selects the built-in corpus of footprints, and the built-in table to lint if no files are given, defaults to kicad).",
+							},
+							&cli.PathFlag{
+								Name:  "corpus",
+								Usage: "CSV file of additional footprints (Package, Value and expected Rotation columns) for the --input-format.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							return lintRotations(c.Args().Slice(), rotationsLintOptions{
+								inputFormat: c.String("input-format"),
+								corpusFile:  c.Path("corpus"),
+							})
+						},
+					},
+					{
+						Name:      "infer",
+						Usage:     "Propose rotation corrections for footprints without a rule, inferred from the location of pin 1 and the centroid of the footprint (for review).",
+						ArgsUsage: "<.kicad_pcb or .kicad_mod file>...",
+						Flags: []cli.Flag{
+							&cli.PathFlag{
+								Name:  "rotations",
+								Usage: "CSV file of additional rotation corrections.",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: formatFlagUsage,
+								Value: "csv",
+							},
+							&cli.PathFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Write the proposals to a file instead of stdout.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							return inferRotations(c.Args().Slice(), rotationsInferOptions{
+								rotationsFile:     c.Path("rotations"),
+								rotationOverrides: projectConfig(c).Rotations,
+								format:            c.String("format"),
+								output:            c.Path("output"),
+							})
+						},
+					},
+					{
+						Name:      "import",
+						Usage:     "Convert rotation corrections from other schemas (eg. the legacy \"Footprint pattern\" schema, or third-party tables) into the rotation correction format.",
+						ArgsUsage: "<file>...",
+						Flags: []cli.Flag{
+							&cli.PathFlag{
+								Name:  "into",
+								Usage: "Existing rotation corrections to merge the imported rules into (its rules take precedence).",
+							},
+							&cli.PathFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Write the rotation corrections to a file instead of stdout.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							return importRotations(c.Args().Slice(), rotationsImportOptions{
+								into:   c.Path("into"),
+								output: c.Path("output"),
+							})
 						},
 					},
 				},
@@ -70,84 +366,10 @@ func main() {
 		},
 	}
 
+	withProjectConfig(app.Commands)
+
 	if err := app.Run(os.Args); err != nil {
 		slog.Error("Error running app", slog.Any("error", err))
 		os.Exit(1)
 	}
 }
-
-func convertKiCadBOM(file string) error {
-	slog.Info("Converting BOM", slog.Any("file", file))
-
-	entries, err := bom.LoadFromCSV(file)
-	if err != nil {
-		return fmt.Errorf("error loading BOM: %w", err)
-	}
-
-	f, err := os.Create(strings.TrimSuffix(file, ".csv") + ".jlcpcb.csv")
-	if err != nil {
-		return fmt.Errorf("error creating output file: %w", err)
-	}
-	defer f.Close()
-
-	w := csv.NewWriter(f)
-	defer w.Flush()
-
-	if err := w.Write([]string{"Comment", "Designator", "Footprint", "LCSC Part Number"}); err != nil {
-		return fmt.Errorf("error writing header: %w", err)
-	}
-
-	for _, entry := range entries {
-		if err := w.Write([]string{
-			entry.Value,
-			entry.Reference,
-			entry.Footprint,
-			entry.LCSC,
-		}); err != nil {
-			return fmt.Errorf("error writing record: %w", err)
-		}
-	}
-
-	return nil
-}
-
-func convertKiCadComponentPlacements(file string) error {
-	slog.Info("Converting component placement", slog.Any("file", file))
-
-	placements, err := placement.LoadFromCSV(file)
-	if err != nil {
-		return fmt.Errorf("error loading component placements: %w", err)
-	}
-
-	f, err := os.Create(strings.TrimSuffix(file, ".csv") + ".jlcpcb.csv")
-	if err != nil {
-		return fmt.Errorf("error creating output file: %w", err)
-	}
-	defer f.Close()
-
-	w := csv.NewWriter(f)
-	defer w.Flush()
-
-	if err := w.Write([]string{"Designator", "Mid X", "Mid Y", "Layer", "Rotation"}); err != nil {
-		return fmt.Errorf("error writing header: %w", err)
-	}
-
-	titleCaser := cases.Title(language.English, cases.Compact)
-
-	for _, placement := range placements {
-		// Fixup differences between KiCad and JLCPCB rotations/placements.
-		placement := jlcpcb.ApplyRotationCorrection(placement)
-
-		if err := w.Write([]string{
-			placement.Ref,
-			strconv.FormatFloat(placement.PosX, 'f', -1, 64),
-			strconv.FormatFloat(placement.PosY, 'f', -1, 64),
-			titleCaser.String(placement.Side),
-			strconv.FormatFloat(placement.Rot, 'f', -1, 64),
-		}); err != nil {
-			return fmt.Errorf("error writing record: %w", err)
-		}
-	}
-
-	return nil
-}
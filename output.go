@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"gopkg.in/yaml.v3"
+)
+
+// formatFlagUsage is the usage text for the --format flag of commands that
+// emit tabular data.
+const formatFlagUsage = "Output format (csv, json or yaml)."
+
+// convertFormatFlagUsage is the usage text for the --format flag of the
+// convert commands.
+const convertFormatFlagUsage = "Output format (csv, xlsx, json or yaml)."
+
+// checkConvertFormat checks the --format of the convert commands (before any
+// output file is created).
+func checkConvertFormat(format string) error {
+	switch format {
+	case "csv", "xlsx", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// checkReportFormat checks the --format of commands that emit tabular data
+// (before any output file is created).
+func checkReportFormat(format string) error {
+	switch format {
+	case "csv", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// report is the structured (JSON/YAML) output of a conversion or check.
+type report[T any] struct {
+	Entries  []T      `json:"entries" yaml:"entries"`
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// writeReport writes the entries as CSV, or the entries and warnings as JSON
+// or YAML.
+func writeReport[T any](w io.Writer, format string, entries []T, warnings []string) error {
+	switch format {
+	case "csv":
+		return csvx.Marshal(w, entries)
+	case "json":
+		return writeJSON(w, report[T]{Entries: entries, Warnings: warnings})
+	case "yaml":
+		return writeYAML(w, report[T]{Entries: entries, Warnings: warnings})
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// profileFlagUsage is the usage text for the --profile flag of the convert
+// commands.
+const profileFlagUsage = "Assembly profile (jlcpcb, pcbway, seeed, macrofab, a profile from the project configuration or a YAML/TOML profile file)."
+
+// resolveProfile finds an assembly profile by name, the custom profiles (eg.
+// from the project configuration) take precedence over the built-in profiles.
+// If the name is a YAML or TOML file, the profile is loaded from it.
+func resolveProfile(name string, custom []assembly.Profile) (*assembly.Profile, error) {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".toml":
+		p, err := assembly.LoadProfile(name)
+		if err != nil {
+			return nil, fmt.Errorf("error loading assembly profile: %w", err)
+		}
+		return p, nil
+	}
+
+	for i := range custom {
+		if custom[i].Name == name {
+			return &custom[i], nil
+		}
+	}
+
+	p, ok := assembly.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown assembly profile: %s", name)
+	}
+
+	return p, nil
+}
+
+// outputPath returns the path of the converted version of a file (for the
+// given assembly profile), replacing the file's extension.
+func outputPath(file, profile, format string) string {
+	return strings.TrimSuffix(file, filepath.Ext(file)) + "." + profile + "." + format
+}
+
+// writeJSON writes a value to w as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("error writing JSON: %w", err)
+	}
+	return nil
+}
+
+// writeYAML writes a value to w as YAML.
+func writeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("error writing YAML: %w", err)
+	}
+	return enc.Close()
+}
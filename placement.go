@@ -0,0 +1,269 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/dpeckett/jlcfabtool/config"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/dpeckett/jlcfabtool/kicad/footprint"
+	"github.com/dpeckett/jlcfabtool/kicad/placement"
+	"github.com/dpeckett/jlcfabtool/spreadsheet"
+)
+
+type placementConvertOptions struct {
+	inputFormat   string
+	rotationsFile string
+	// rotationOverrides are additional rotation corrections (eg. from the
+	// project configuration).
+	rotationOverrides jlcpcb.RotationTable
+	// bomFile is a BOM used to exclude the placements of parts marked DNP.
+	bomFile   string
+	bomPolicy bomPolicy
+	// boardFile is a KiCad board, used to offset the center of footprints
+	// without a rotation correction to the centroid of their geometry.
+	boardFile string
+	// origin is subtracted from every placement (eg. from the project
+	// configuration).
+	origin  *config.Origin
+	profile *assembly.Profile
+	format  string
+}
+
+func convertComponentPlacements(files []string, opts placementConvertOptions) error {
+	if len(files) == 0 {
+		return fmt.Errorf("expected at least one component placement file")
+	}
+
+	if err := checkConvertFormat(opts.format); err != nil {
+		return err
+	}
+
+	var placements []placement.Placement
+	var inputFormat string
+	for _, file := range files {
+		slog.Info("Converting component placement", slog.Any("file", file))
+
+		filePlacements, fileFormat, err := placement.Load(file, opts.inputFormat)
+		if err != nil {
+			return fmt.Errorf("error loading component placements: %w", err)
+		}
+
+		if inputFormat != "" && fileFormat != inputFormat {
+			return fmt.Errorf("component placement files are in different formats: %s and %s", inputFormat, fileFormat)
+		}
+		inputFormat = fileFormat
+
+		placements = append(placements, filePlacements...)
+	}
+
+	slog.Info("Loaded component placements", slog.String("format", inputFormat), slog.Int("placements", len(placements)))
+
+	var warnings []string
+	if opts.origin != nil {
+		placements = placement.Translate(placements, -opts.origin.X, -opts.origin.Y)
+		warnings = append(warnings, fmt.Sprintf("placements are relative to the origin (%g, %g)", opts.origin.X, opts.origin.Y))
+	}
+
+	if opts.bomFile != "" {
+		entries, _, err := bom.Load(opts.bomFile, "")
+		if err != nil {
+			return fmt.Errorf("error loading BOM: %w", err)
+		}
+
+		if entries, err = opts.bomPolicy.apply(entries); err != nil {
+			return err
+		}
+		warnings = append(warnings, bom.Check(entries)...)
+
+		var refs []string
+		_, dnp := bom.SplitDNP(entries)
+		for _, entry := range dnp {
+			refs = append(refs, entry.Reference...)
+		}
+
+		var excluded []placement.Placement
+		placements, excluded = placement.Exclude(placements, refs)
+		if len(excluded) > 0 {
+			var excludedRefs bom.Designators
+			for _, excludedPlacement := range excluded {
+				excludedRefs = append(excludedRefs, excludedPlacement.Ref)
+			}
+			warnings = append(warnings, fmt.Sprintf("excluded the placements of DNP parts: %s", excludedRefs))
+		}
+	}
+
+	rotationOverrides := opts.rotationOverrides
+	if opts.rotationsFile != "" {
+		f, err := os.Open(opts.rotationsFile)
+		if err != nil {
+			return fmt.Errorf("error opening rotation corrections: %w", err)
+		}
+		defer f.Close()
+
+		corrections, err := jlcpcb.LoadRotationTable(f)
+		if err != nil {
+			return fmt.Errorf("error loading rotation corrections: %w", err)
+		}
+
+		rotationOverrides = append(rotationOverrides, corrections...)
+	}
+
+	var rotations jlcpcb.RotationTable
+	if opts.profile.Rotations == assembly.RotationsJLCPCB {
+		var ok bool
+		rotations, ok = jlcpcb.RotationTableFor(inputFormat)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("no built-in rotation corrections for format: %s", inputFormat))
+		}
+	}
+
+	tables := []jlcpcb.RotationTable{rotationOverrides, rotations}
+	if opts.boardFile != "" {
+		footprints, err := footprint.Load(opts.boardFile)
+		if err != nil {
+			return fmt.Errorf("error loading footprints %s: %w", opts.boardFile, err)
+		}
+
+		centroids := jlcpcb.CentroidCorrections(footprints, tables...)
+		for _, correction := range centroids {
+			warnings = append(warnings, fmt.Sprintf("no rotation correction for %q, offsetting the center to the footprint's centroid (%g, %g)",
+				correction.PackagePattern, *correction.CenterX, *correction.CenterY))
+		}
+
+		tables = append(tables, centroids)
+	}
+
+	// Fixup differences between the EDA tool's and the assembler's rotations/placements.
+	converted := jlcpcb.ConvertPlacements(placements, tables...)
+
+	for _, warning := range warnings {
+		slog.Warn(warning)
+	}
+
+	f, err := os.Create(outputPath(placementsBaseName(files), opts.profile.Name, opts.format))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer f.Close()
+
+	switch opts.format {
+	case "csv":
+		return opts.profile.WritePlacements(f, converted)
+	case "xlsx":
+		header, rows := opts.profile.PlacementTable(converted)
+		return spreadsheet.Write(f, []spreadsheet.Sheet{{Name: "CPL", Header: header, Rows: spreadsheet.TextRows(rows)}})
+	default:
+		return writeReport(f, opts.format, opts.profile.PlacementRecords(converted), warnings)
+	}
+}
+
+// placementsBaseName returns the file that the converted placements are named
+// after. Separate front and back files (eg. "board-top.pos" and
+// "board-bottom.pos") are named after the board.
+func placementsBaseName(files []string) string {
+	if len(files) == 1 {
+		return files[0]
+	}
+
+	ext := filepath.Ext(files[0])
+	base := strings.TrimSuffix(files[0], ext)
+	for _, side := range []string{"-top", "-bottom", "-front", "-back"} {
+		if trimmed, ok := strings.CutSuffix(base, side); ok {
+			return trimmed + ext
+		}
+	}
+
+	return files[0]
+}
+
+type placementDiffOptions struct {
+	inputFormat string
+	tolerance   placement.Tolerance
+	format      string
+}
+
+func diffComponentPlacements(oldFile, newFile string, opts placementDiffOptions) error {
+	oldPlacements, _, err := placement.Load(oldFile, opts.inputFormat)
+	if err != nil {
+		return fmt.Errorf("error loading component placements %s: %w", oldFile, err)
+	}
+
+	newPlacements, _, err := placement.Load(newFile, opts.inputFormat)
+	if err != nil {
+		return fmt.Errorf("error loading component placements %s: %w", newFile, err)
+	}
+
+	changes := placement.Diff(oldPlacements, newPlacements, opts.tolerance)
+
+	switch opts.format {
+	case "text":
+		return writePlacementDiffText(os.Stdout, changes, opts.tolerance)
+	case "json":
+		return writeJSON(os.Stdout, changes)
+	case "yaml":
+		return writeYAML(os.Stdout, changes)
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.format)
+	}
+}
+
+func writePlacementDiffText(w io.Writer, changes []placement.PlacementChange, tol placement.Tolerance) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for _, change := range changes {
+		var err error
+		switch change.Change {
+		case placement.ChangeAdded:
+			_, err = fmt.Fprintf(tw, "+\t%s\t%s\t(%g, %g)\t%g°\t%s\n",
+				change.Ref, change.New.Package, change.New.PosX, change.New.PosY, change.New.Rot, change.New.Side)
+		case placement.ChangeRemoved:
+			_, err = fmt.Fprintf(tw, "-\t%s\t%s\t(%g, %g)\t%g°\t%s\n",
+				change.Ref, change.Old.Package, change.Old.PosX, change.Old.PosY, change.Old.Rot, change.Old.Side)
+		case placement.ChangeMoved:
+			var details []string
+			if change.Distance > tol.Position {
+				details = append(details, fmt.Sprintf("moved %.3fmm", change.Distance))
+			}
+			if math.Abs(change.Rotation) > tol.Rotation {
+				details = append(details, fmt.Sprintf("rotated %g°", change.Rotation))
+			}
+			if change.Flipped {
+				details = append(details, fmt.Sprintf("flipped %s -> %s", change.Old.Side, change.New.Side))
+			}
+			_, err = fmt.Fprintf(tw, "~\t%s\t(%g, %g) -> (%g, %g)\t%g° -> %g°\t%s\n",
+				change.Ref, change.Old.PosX, change.Old.PosY, change.New.PosX, change.New.PosY,
+				change.Old.Rot, change.New.Rot, strings.Join(details, ", "))
+		}
+		if err != nil {
+			return fmt.Errorf("error writing diff: %w", err)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/assembly"
+	"github.com/dpeckett/jlcfabtool/config"
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertComponentPlacementsReport(t *testing.T) {
+	dir := t.TempDir()
+
+	placementsFile := filepath.Join(dir, "board-pos.csv")
+	require.NoError(t, os.WriteFile(placementsFile, []byte(`Ref,Val,Package,PosX,PosY,Rot,Side
+R1,10k,R_0603_1608Metric,10,20,0,top
+R2,10k,R_0603_1608Metric,15,20,0,top
+`), 0o644))
+
+	bomFile := filepath.Join(dir, "bom.csv")
+	require.NoError(t, os.WriteFile(bomFile, []byte(`"Reference","Value","Footprint","Qty","DNP","LCSC PN"
+"R1","10k","R_0603_1608Metric","1","","C25804"
+"R2","10k","R_0603_1608Metric","1","DNP","C25804"
+`), 0o644))
+
+	err := convertComponentPlacements([]string{placementsFile}, placementConvertOptions{
+		bomFile: bomFile,
+		origin:  &config.Origin{X: 5, Y: 10},
+		profile: &assembly.MacroFab,
+		format:  "json",
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "board-pos.macrofab.json"))
+	require.NoError(t, err)
+
+	var r report[assembly.Record[jlcpcb.PlacementEntry]]
+	require.NoError(t, json.Unmarshal(data, &r))
+
+	require.Len(t, r.Entries, 1)
+	assert.Equal(t, "R1", r.Entries[0].Entry.Designator)
+	// MacroFab uses mils.
+	assert.Equal(t, "196.8504", r.Entries[0].Columns["X-Loc"])
+	assert.Equal(t, 5.0, r.Entries[0].Entry.MidX)
+
+	assert.Equal(t, []string{
+		"placements are relative to the origin (5, 10)",
+		"excluded the placements of DNP parts: R2",
+	}, r.Warnings)
+}
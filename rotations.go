@@ -0,0 +1,298 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/dpeckett/jlcfabtool/jlcpcb"
+	"github.com/dpeckett/jlcfabtool/kicad/footprint"
+)
+
+type rotationsLintOptions struct {
+	// inputFormat selects the built-in corpus used for rotation files (and the
+	// built-in table to lint if no files are given).
+	inputFormat string
+	corpusFile  string
+}
+
+// lintRotations lints rotation correction files, or the built-in tables if no
+// files are given. The expected rotations of a corpus only hold for one input
+// format, so if a corpus or input format is given only that format's built-in
+// table is linted.
+func lintRotations(files []string, opts rotationsLintOptions) error {
+	var extra []jlcpcb.CorpusEntry
+	if opts.corpusFile != "" {
+		f, err := os.Open(opts.corpusFile)
+		if err != nil {
+			return fmt.Errorf("error opening corpus: %w", err)
+		}
+		defer f.Close()
+
+		extra, err = jlcpcb.LoadCorpus(f)
+		if err != nil {
+			return fmt.Errorf("error loading corpus: %w", err)
+		}
+	}
+
+	inputFormat := opts.inputFormat
+	if inputFormat == "" {
+		inputFormat = "kicad"
+	}
+
+	var count int
+	if len(files) == 0 {
+		formats := jlcpcb.BuiltinRotationTables()
+		if opts.inputFormat != "" || opts.corpusFile != "" {
+			if !slices.Contains(formats, inputFormat) {
+				return fmt.Errorf("no built-in rotation corrections for format: %s", inputFormat)
+			}
+			formats = []string{inputFormat}
+		}
+
+		for _, format := range formats {
+			issues, err := jlcpcb.LintBuiltinRotationTable(format, extra...)
+			if err != nil {
+				return fmt.Errorf("error linting built-in rotation corrections: %w", err)
+			}
+
+			if err := writeLintIssues(os.Stdout, format+"_rotations.csv", issues); err != nil {
+				return err
+			}
+			count += len(issues)
+		}
+	} else {
+		builtin, ok := jlcpcb.CorpusFor(inputFormat)
+		if !ok {
+			slog.Warn("No built-in corpus for format", slog.String("format", inputFormat))
+		}
+
+		// The expected rotations of the built-in corpus are for the built-in
+		// table, so only use it to exercise the rules.
+		var corpus []jlcpcb.CorpusEntry
+		for _, entry := range builtin {
+			entry.Rotation = nil
+			corpus = append(corpus, entry)
+		}
+		corpus = append(corpus, extra...)
+
+		for _, file := range files {
+			issues, err := lintRotationsFile(file, corpus)
+			if err != nil {
+				return err
+			}
+
+			if err := writeLintIssues(os.Stdout, file, issues); err != nil {
+				return err
+			}
+			count += len(issues)
+		}
+	}
+
+	if count > 0 {
+		return fmt.Errorf("found %d issues in the rotation corrections", count)
+	}
+
+	slog.Info("No issues found in the rotation corrections")
+
+	return nil
+}
+
+func lintRotationsFile(file string, corpus []jlcpcb.CorpusEntry) ([]jlcpcb.LintIssue, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("error opening rotation corrections: %w", err)
+	}
+	defer f.Close()
+
+	issues, err := jlcpcb.LintRotationTable(f, corpus)
+	if err != nil {
+		return nil, fmt.Errorf("error linting rotation corrections %s: %w", file, err)
+	}
+
+	return issues, nil
+}
+
+func writeLintIssues(w io.Writer, name string, issues []jlcpcb.LintIssue) error {
+	for _, issue := range issues {
+		var err error
+		if issue.Line == 0 {
+			_, err = fmt.Fprintf(w, "%s: %s\n", name, issue.Message)
+		} else {
+			_, err = fmt.Fprintf(w, "%s:%d: %s\n", name, issue.Line, issue.Message)
+		}
+		if err != nil {
+			return fmt.Errorf("error writing issues: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type rotationsImportOptions struct {
+	// into is an existing table to merge the imported rules into (its rules take
+	// precedence).
+	into   string
+	output string
+}
+
+// importRotations converts rotation correction tables in other schemas into a
+// single table, removing duplicates and reporting conflicting rules.
+func importRotations(files []string, opts rotationsImportOptions) error {
+	if len(files) == 0 {
+		return fmt.Errorf("no rotation correction files specified")
+	}
+
+	var tables []jlcpcb.RotationTable
+	if opts.into != "" {
+		f, err := os.Open(opts.into)
+		if err != nil {
+			return fmt.Errorf("error opening rotation corrections: %w", err)
+		}
+		defer f.Close()
+
+		table, err := jlcpcb.LoadRotationTable(f)
+		if err != nil {
+			return fmt.Errorf("error loading rotation corrections %s: %w", opts.into, err)
+		}
+		tables = append(tables, table)
+	}
+
+	for _, file := range files {
+		table, err := importRotationsFile(file)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, table)
+	}
+
+	merged, conflicts := jlcpcb.MergeRotationTables(tables...)
+	for _, conflict := range conflicts {
+		slog.Warn(conflict.String())
+	}
+
+	slog.Info("Imported rotation corrections",
+		slog.Int("rules", len(merged)), slog.Int("conflicts", len(conflicts)))
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	return jlcpcb.WriteRotationTable(w, merged)
+}
+
+func importRotationsFile(file string) (jlcpcb.RotationTable, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("error opening rotation corrections: %w", err)
+	}
+	defer f.Close()
+
+	table, issues, err := jlcpcb.ImportRotationTable(f)
+	if err != nil {
+		return nil, fmt.Errorf("error importing rotation corrections %s: %w", file, err)
+	}
+
+	for _, issue := range issues {
+		slog.Warn("Skipping rule", slog.String("file", file),
+			slog.Int("line", issue.Line), slog.String("reason", issue.Message))
+	}
+
+	return table, nil
+}
+
+type rotationsInferOptions struct {
+	rotationsFile string
+	// rotationOverrides are additional rotation corrections (eg. from the
+	// project configuration).
+	rotationOverrides jlcpcb.RotationTable
+	format            string
+	output            string
+}
+
+// inferRotations proposes rotation corrections (and center offsets) for the
+// footprints of KiCad boards (or footprint files) that don't have a rule, for
+// review. Rules whose center offsets disagree with the footprints' geometry are
+// reported as warnings.
+func inferRotations(files []string, opts rotationsInferOptions) error {
+	if len(files) == 0 {
+		return fmt.Errorf("no KiCad board or footprint files specified")
+	}
+
+	var footprints []footprint.Footprint
+	for _, file := range files {
+		fileFootprints, err := footprint.Load(file)
+		if err != nil {
+			return fmt.Errorf("error loading footprints %s: %w", file, err)
+		}
+
+		footprints = append(footprints, fileFootprints...)
+	}
+
+	tables := []jlcpcb.RotationTable{opts.rotationOverrides}
+	if opts.rotationsFile != "" {
+		f, err := os.Open(opts.rotationsFile)
+		if err != nil {
+			return fmt.Errorf("error opening rotation corrections: %w", err)
+		}
+		defer f.Close()
+
+		corrections, err := jlcpcb.LoadRotationTable(f)
+		if err != nil {
+			return fmt.Errorf("error loading rotation corrections: %w", err)
+		}
+
+		tables = append(tables, corrections)
+	}
+
+	builtin, _ := jlcpcb.RotationTableFor("kicad")
+	tables = append(tables, builtin)
+
+	proposals, warnings := jlcpcb.ProposeRotations(footprints, tables...)
+	warnings = append(warnings, jlcpcb.CheckCentroids(footprints, tables...)...)
+	for _, warning := range warnings {
+		slog.Warn(warning)
+	}
+
+	slog.Info("Inferred rotation corrections for review",
+		slog.Int("footprints", len(footprints)), slog.Int("proposals", len(proposals)))
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	return writeReport(w, opts.format, proposals, warnings)
+}
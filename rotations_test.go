@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRotationsCorpus(t *testing.T) {
+	corpusFile := filepath.Join(t.TempDir(), "corpus.csv")
+	err := os.WriteFile(corpusFile, []byte(`"Package","Value","Rotation"
+"IDC-Header_2x05_P2.54mm_Vertical","",-90
+`), 0o644)
+	require.NoError(t, err)
+
+	t.Run("Builtin", func(t *testing.T) {
+		// The corpus is for KiCad footprints, so only the KiCad table is linted.
+		err := lintRotations(nil, rotationsLintOptions{corpusFile: corpusFile})
+		require.NoError(t, err)
+	})
+
+	t.Run("Other Format", func(t *testing.T) {
+		err := lintRotations(nil, rotationsLintOptions{inputFormat: "eagle", corpusFile: corpusFile})
+		require.ErrorContains(t, err, "found 1 issues")
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		err := lintRotations(nil, rotationsLintOptions{inputFormat: "gerber"})
+		require.Error(t, err)
+	})
+}
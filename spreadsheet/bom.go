@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package spreadsheet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dpeckett/jlcfabtool/csvx"
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/xuri/excelize/v2"
+)
+
+// maxHeaderRow is how far down a sheet to look for the header row.
+const maxHeaderRow = 20
+
+func init() {
+	bom.RegisterReader(&BOMReader{})
+}
+
+// ReadBOM reads a BOM from an xlsx workbook. The header row is detected (so
+// there may be a title or notes above the table) and the rows are unmarshalled
+// using the same csv tags as a KiCad BOM. If sheet is empty, the first sheet
+// with a BOM header row is used.
+func ReadBOM(r io.Reader, sheet string) ([]bom.Entry, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("could not open workbook: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if sheet != "" {
+		if idx, err := f.GetSheetIndex(sheet); err != nil || idx < 0 {
+			return nil, fmt.Errorf("sheet not found: %s", sheet)
+		}
+		sheets = []string{sheet}
+	}
+
+	for _, name := range sheets {
+		rows, err := f.GetRows(name)
+		if err != nil {
+			return nil, fmt.Errorf("could not read sheet %s: %w", name, err)
+		}
+
+		header := csvx.FindHeader[bom.Entry](rows, maxHeaderRow)
+		if header < 0 {
+			continue
+		}
+
+		entries, err := csvx.UnmarshalRecords[bom.Entry](rows[header:])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse sheet %s: %w", name, err)
+		}
+
+		return entries, nil
+	}
+
+	if sheet != "" {
+		return nil, fmt.Errorf("could not find header row in sheet %s", sheet)
+	}
+
+	return nil, fmt.Errorf("could not find a sheet with a BOM header row")
+}
+
+// BOMReader reads BOMs from xlsx workbooks.
+type BOMReader struct {
+	// Sheet is the name of the sheet holding the BOM (defaults to the first
+	// sheet with a BOM header row).
+	Sheet string
+}
+
+func (r *BOMReader) Name() string {
+	return "xlsx"
+}
+
+func (r *BOMReader) Sniff(name string, head []byte) bool {
+	// xlsx files are zip archives.
+	return strings.EqualFold(filepath.Ext(name), ".xlsx") ||
+		(bytes.HasPrefix(head, []byte("PK\x03\x04")) && bytes.Contains(head, []byte("xl/")))
+}
+
+func (r *BOMReader) Read(rd io.Reader) ([]bom.Entry, error) {
+	return ReadBOM(rd, r.Sheet)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package spreadsheet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/kicad/bom"
+	"github.com/dpeckett/jlcfabtool/spreadsheet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// bomWorkbook creates a workbook with a notes sheet, followed by a BOM sheet
+// with a title above the table.
+func bomWorkbook(t *testing.T) []byte {
+	f := excelize.NewFile()
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	require.NoError(t, f.SetSheetName("Sheet1", "Notes"))
+	require.NoError(t, f.SetCellStr("Notes", "A1", "Revision B"))
+
+	_, err := f.NewSheet("Parts")
+	require.NoError(t, err)
+
+	rows := [][]any{
+		{"Widget BOM"},
+		{},
+		{"Reference", "Value", "Footprint", "Qty", "LCSC PN"},
+		{"C1,C2", "100n", "Capacitor_SMD:C_0603_1608Metric", 2, "C14663"},
+		{"R1", "10k", "Resistor_SMD:R_0603_1608Metric", 1, "C25804"},
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		require.NoError(t, err)
+		require.NoError(t, f.SetSheetRow("Parts", cell, &row))
+	}
+
+	var buf bytes.Buffer
+	_, err = f.WriteTo(&buf)
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+func TestReadBOM(t *testing.T) {
+	data := bomWorkbook(t)
+
+	expected := []bom.Entry{
+		{Reference: bom.Designators{"C1", "C2"}, Value: "100n", Footprint: "Capacitor_SMD:C_0603_1608Metric", Qty: 2, LCSC: "C14663"},
+		{Reference: bom.Designators{"R1"}, Value: "10k", Footprint: "Resistor_SMD:R_0603_1608Metric", Qty: 1, LCSC: "C25804"},
+	}
+
+	// Detect the sheet.
+	entries, err := spreadsheet.ReadBOM(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	assert.Equal(t, expected, entries)
+
+	// Select the sheet.
+	entries, err = spreadsheet.ReadBOM(bytes.NewReader(data), "Parts")
+	require.NoError(t, err)
+	assert.Equal(t, expected, entries)
+
+	_, err = spreadsheet.ReadBOM(bytes.NewReader(data), "Notes")
+	assert.Error(t, err)
+
+	_, err = spreadsheet.ReadBOM(bytes.NewReader(data), "Missing")
+	assert.Error(t, err)
+}
+
+func TestBOMReaderSniff(t *testing.T) {
+	data := bomWorkbook(t)
+
+	r := &spreadsheet.BOMReader{}
+	assert.True(t, r.Sniff("bom.xlsx", nil))
+	assert.True(t, r.Sniff("bom", data))
+	assert.False(t, r.Sniff("bom.csv", []byte("Reference,Value\n")))
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package spreadsheet writes Excel (.xlsx) workbooks.
+package spreadsheet
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxColumnWidth limits how wide a column is made to fit its contents.
+const maxColumnWidth = 60
+
+// Sheet is a single worksheet, with a header row.
+type Sheet struct {
+	Name   string
+	Header []string
+	// Rows holds the cell values. Strings are always stored as text (so Excel
+	// won't mangle leading zeros or designator lists), other values (eg. float64)
+	// are stored as is and nil cells are left empty.
+	Rows [][]any
+}
+
+// TextRows converts rows of strings into sheet rows.
+func TextRows(rows [][]string) [][]any {
+	converted := make([][]any, len(rows))
+	for i, row := range rows {
+		converted[i] = make([]any, len(row))
+		for j, cell := range row {
+			converted[i][j] = cell
+		}
+	}
+
+	return converted
+}
+
+// Write writes the sheets to w as an xlsx workbook.
+func Write(w io.Writer, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("no sheets to write")
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		NumFmt:    49, // Text ("@")
+		Alignment: &excelize.Alignment{Vertical: "top"},
+	})
+	if err != nil {
+		return fmt.Errorf("could not create style: %w", err)
+	}
+
+	textStyle, err := f.NewStyle(&excelize.Style{NumFmt: 49})
+	if err != nil {
+		return fmt.Errorf("could not create style: %w", err)
+	}
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet.Name); err != nil {
+				return fmt.Errorf("could not name sheet: %w", err)
+			}
+		} else if _, err := f.NewSheet(sheet.Name); err != nil {
+			return fmt.Errorf("could not create sheet: %w", err)
+		}
+
+		if err := writeSheet(f, sheet, headerStyle, textStyle); err != nil {
+			return fmt.Errorf("could not write sheet %s: %w", sheet.Name, err)
+		}
+	}
+
+	if _, err := f.WriteTo(w); err != nil {
+		return fmt.Errorf("could not write workbook: %w", err)
+	}
+
+	return nil
+}
+
+func writeSheet(f *excelize.File, sheet Sheet, headerStyle, textStyle int) error {
+	widths := make([]int, len(sheet.Header))
+
+	setCell := func(col, row int, value any, style int) error {
+		if value == nil {
+			return nil
+		}
+
+		cell, err := excelize.CoordinatesToCellName(col+1, row+1)
+		if err != nil {
+			return err
+		}
+
+		if s, ok := value.(string); ok {
+			if err := f.SetCellStr(sheet.Name, cell, s); err != nil {
+				return err
+			}
+			if err := f.SetCellStyle(sheet.Name, cell, cell, style); err != nil {
+				return err
+			}
+		} else if err := f.SetCellValue(sheet.Name, cell, value); err != nil {
+			return err
+		}
+
+		if col < len(widths) {
+			widths[col] = max(widths[col], utf8.RuneCountInString(fmt.Sprint(value)))
+		}
+
+		return nil
+	}
+
+	for col, heading := range sheet.Header {
+		if err := setCell(col, 0, heading, headerStyle); err != nil {
+			return err
+		}
+	}
+
+	for row, values := range sheet.Rows {
+		for col, value := range values {
+			if err := setCell(col, row+1, value, textStyle); err != nil {
+				return err
+			}
+		}
+	}
+
+	for col, width := range widths {
+		name, err := excelize.ColumnNumberToName(col + 1)
+		if err != nil {
+			return err
+		}
+
+		if err := f.SetColWidth(sheet.Name, name, name, float64(min(width+2, maxColumnWidth))); err != nil {
+			return err
+		}
+	}
+
+	// Keep the header visible while scrolling.
+	return f.SetPanes(sheet.Name, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+}
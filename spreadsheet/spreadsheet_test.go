@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2026 Damian Peckett <damian@pecke.tt>.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published
+ * by the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package spreadsheet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dpeckett/jlcfabtool/spreadsheet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := spreadsheet.Write(&buf, []spreadsheet.Sheet{
+		{
+			Name:   "BOM",
+			Header: []string{"Comment", "Designator", "LCSC Part Number"},
+			Rows: spreadsheet.TextRows([][]string{
+				{"0805", "R1,R2", "C17414"},
+				{"1e3", "C1", "C0001"},
+			}),
+		},
+		{
+			Name:   "Cost",
+			Header: []string{"LCSC Part Number", "Total"},
+			Rows:   [][]any{{"C17414", 0.25}},
+		},
+	})
+	require.NoError(t, err)
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+
+	assert.Equal(t, []string{"BOM", "Cost"}, f.GetSheetList())
+
+	rows, err := f.GetRows("BOM")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Comment", "Designator", "LCSC Part Number"},
+		{"0805", "R1,R2", "C17414"},
+		{"1e3", "C1", "C0001"},
+	}, rows)
+
+	// Numeric looking values are stored as text.
+	cellType, err := f.GetCellType("BOM", "A2")
+	require.NoError(t, err)
+	assert.NotEqual(t, excelize.CellTypeNumber, cellType)
+	assert.NotEqual(t, excelize.CellTypeUnset, cellType)
+
+	value, err := f.GetCellValue("Cost", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, "0.25", value)
+
+	cellType, err = f.GetCellType("Cost", "B2")
+	require.NoError(t, err)
+	assert.NotEqual(t, excelize.CellTypeSharedString, cellType)
+}
+
+func TestWriteNoSheets(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, spreadsheet.Write(&buf, nil))
+}